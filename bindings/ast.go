@@ -16,6 +16,9 @@ type Identifier struct {
 	Name    string
 	Package *types.Package
 	Prefix  string
+	// Namespace is the typescript namespace the identifier is declared in.
+	// References from outside the namespace are qualified, eg: 'pkg.Type'.
+	Namespace string
 }
 
 // GoName should be a unique name for the identifier across all Go packages.
@@ -44,6 +47,15 @@ func (i Identifier) Ref() string {
 	return i.Prefix + i.Name
 }
 
+// Qualified returns the reference qualified by the namespace, if one is set.
+// This is unique across all namespaces.
+func (i Identifier) Qualified() string {
+	if i.Namespace == "" {
+		return i.Ref()
+	}
+	return i.Namespace + "." + i.Ref()
+}
+
 type HeritageType string
 
 const (
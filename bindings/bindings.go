@@ -2,6 +2,8 @@ package bindings
 
 import (
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/dop251/goja"
@@ -31,6 +33,12 @@ func (b *Bindings) ToTypescriptNode(ety Node) (*goja.Object, error) {
 		siObj, err = b.HeritageClause(node)
 	case *PropertySignature:
 		siObj, err = b.PropertySignature(node)
+	case *MethodSignature:
+		siObj, err = b.MethodSignature(node)
+	case *PropertyAssignment:
+		siObj, err = b.PropertyAssignment(node)
+	case *Parameter:
+		siObj, err = b.Parameter(node)
 	case *TypeParameter:
 		siObj, err = b.TypeParameter(node)
 	case DeclarationType:
@@ -60,7 +68,12 @@ func (b *Bindings) ToTypescriptNode(ety Node) (*goja.Object, error) {
 
 	// Append any other comments
 	if commented, ok := ety.(Commentable); ok {
-		siObj, err = b.CommentGojaObject(commented.Comments(), siObj)
+		comments := commented.Comments()
+		if prop, ok := ety.(*PropertySignature); ok && !prop.Constraints.IsEmpty() {
+			// Constraints are included in the same JSDoc block as the comments.
+			comments = append(slices.Clone(comments), prop.Constraints.comments()...)
+		}
+		siObj, err = b.CommentGojaObject(comments, siObj)
 		if err != nil {
 			return nil, xerrors.Errorf("comment declaration: %w", err)
 		}
@@ -82,6 +95,10 @@ func (b *Bindings) ToTypescriptDeclarationNode(ety DeclarationType) (*goja.Objec
 		siObj, err = b.VariableStatement(ety)
 	case *Enum:
 		siObj, err = b.EnumDeclaration(ety)
+	case *Namespace:
+		siObj, err = b.Namespace(ety)
+	case *FunctionDeclaration:
+		siObj, err = b.FunctionDeclaration(ety)
 	default:
 		return nil, xerrors.Errorf("unsupported type for declaration type: %T", ety)
 	}
@@ -99,7 +116,13 @@ func (b *Bindings) ToTypescriptExpressionNode(ety ExpressionType) (*goja.Object,
 	case *ReferenceType:
 		siObj, err = b.Reference(ety)
 	case *TupleType:
+		if ety.Elements != nil {
+			siObj, err = b.HeterogeneousTuple(ety.Elements)
+			break
+		}
 		siObj, err = b.Tuple(ety.Length, ety.Node)
+	case *FunctionType:
+		siObj, err = b.FunctionType(ety)
 	case *ArrayType:
 		siObj, err = b.Array(ety.Node)
 	case *UnionType:
@@ -117,22 +140,65 @@ func (b *Bindings) ToTypescriptExpressionNode(ety ExpressionType) (*goja.Object,
 		case string:
 			siObj, err = b.StringLiteral(v)
 		case int64:
+			if v < 0 {
+				// Negative numbers are the negation of the positive number.
+				siObj, err = b.PrefixUnary(PrefixUnary(PrefixMinus, &LiteralType{Value: uint64(-v)}))
+				break
+			}
 			siObj, err = b.NumericLiteral(v)
+		case uint64:
+			siObj, err = b.numericLiteral(strconv.FormatUint(v, 10))
 		case float64:
+			if v < 0 {
+				siObj, err = b.PrefixUnary(PrefixUnary(PrefixMinus, &LiteralType{Value: -v}))
+				break
+			}
 			siObj, err = b.FloatLiteral(v)
 		case bool:
-			siObj, err = b.BooleanLiteral(0)
+			siObj, err = b.BooleanLiteral(v)
 		default:
 			return nil, xerrors.Errorf("unsupported literal type: %T", ety.Value)
 		}
 	case *ArrayLiteralType:
 		siObj, err = b.ArrayLiteral(ety)
+	case *ObjectLiteral:
+		siObj, err = b.ObjectLiteral(ety)
+	case *PrefixUnaryExpression:
+		siObj, err = b.PrefixUnary(ety)
+	case *BigIntLiteral:
+		if strings.HasPrefix(ety.Value, "-") {
+			siObj, err = b.PrefixUnary(PrefixUnary(PrefixMinus, &BigIntLiteral{Value: ety.Value[1:]}))
+			break
+		}
+		siObj, err = b.BigIntLiteral(ety)
+	case *TemplateLiteralType:
+		siObj, err = b.TemplateLiteralType(ety)
 	case *OperatorNodeType:
 		siObj, err = b.OperatorNode(ety)
 	case *TypeLiteralNode:
 		siObj, err = b.TypeLiteralNode(ety)
 	case *TypeIntersection:
 		siObj, err = b.TypeIntersection(ety)
+	case *TypeQuery:
+		siObj, err = b.TypeQuery(ety)
+	case *IndexedAccessType:
+		siObj, err = b.IndexedAccessType(ety)
+	case *AsConstExpression:
+		siObj, err = b.AsConst(ety)
+	case *PropertyAccess:
+		siObj, err = b.PropertyAccess(ety)
+	case *IdentifierExpression:
+		siObj, err = b.IdentifierExpression(ety)
+	case *MemberAccess:
+		siObj, err = b.MemberAccess(ety)
+	case *CallExpression:
+		siObj, err = b.CallExpression(ety)
+	case *ArrowFunction:
+		siObj, err = b.ArrowFunction(ety)
+	case *ConditionalExpression:
+		siObj, err = b.ConditionalExpression(ety)
+	case *BinaryExpression:
+		siObj, err = b.BinaryExpression(ety)
 	default:
 		return nil, xerrors.Errorf("unsupported type for field type: %T", ety)
 	}
@@ -169,8 +235,16 @@ func (b *Bindings) Reference(ref *ReferenceType) (*goja.Object, error) {
 		args = append(args, v)
 	}
 
+	var name goja.Value = b.vm.ToValue(ref.Name.Ref())
+	if ref.Name.Namespace != "" && ref.Name.Namespace != b.namespace {
+		name, err = b.QualifiedName(ref.Name.Namespace, ref.Name.Ref())
+		if err != nil {
+			return nil, fmt.Errorf("reference qualified name: %w", err)
+		}
+	}
+
 	res, err := modifier(goja.Undefined(),
-		b.vm.ToValue(ref.Name.Ref()),
+		name,
 		b.vm.NewArray(args...),
 	)
 	if err != nil {
@@ -180,6 +254,21 @@ func (b *Bindings) Reference(ref *ReferenceType) (*goja.Object, error) {
 	return res.ToObject(b.vm), nil
 }
 
+// QualifiedName references a name inside a namespace.
+// pkg.Type
+func (b *Bindings) QualifiedName(namespace string, name string) (*goja.Object, error) {
+	qualifiedF, err := b.f("qualifiedName")
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := qualifiedF(goja.Undefined(), b.vm.ToValue(namespace), b.vm.ToValue(name))
+	if err != nil {
+		return nil, xerrors.Errorf("call qualifiedName: %w", err)
+	}
+	return res.ToObject(b.vm), nil
+}
+
 func (b *Bindings) PropertySignature(sig *PropertySignature) (*goja.Object, error) {
 	propertySignature, err := b.f("propertySignature")
 	if err != nil {
@@ -233,6 +322,15 @@ func (b *Bindings) Interface(ti *Interface) (*goja.Object, error) {
 		fields = append(fields, v)
 	}
 
+	for _, method := range ti.Methods {
+		v, err := b.ToTypescriptNode(method)
+		if err != nil {
+			return nil, err
+		}
+
+		fields = append(fields, v)
+	}
+
 	var typeParams []interface{}
 	for _, tp := range ti.Parameters {
 		v, err := b.ToTypescriptNode(tp)
@@ -342,6 +440,109 @@ func (b *Bindings) Tuple(length int, tupleType ExpressionType) (*goja.Object, er
 	return res.ToObject(b.vm), nil
 }
 
+func (b *Bindings) HeterogeneousTuple(elements []ExpressionType) (*goja.Object, error) {
+	tuple, err := b.f("tupleType")
+	if err != nil {
+		return nil, err
+	}
+
+	var nodes []interface{}
+	for _, elem := range elements {
+		v, err := b.ToTypescriptNode(elem)
+		if err != nil {
+			return nil, fmt.Errorf("tuple element: %w", err)
+		}
+		nodes = append(nodes, v)
+	}
+
+	res, err := tuple(goja.Undefined(), b.vm.NewArray(nodes...))
+	if err != nil {
+		return nil, xerrors.Errorf("call tupleType: %w", err)
+	}
+	return res.ToObject(b.vm), nil
+}
+
+func (b *Bindings) FunctionType(fn *FunctionType) (*goja.Object, error) {
+	functionF, err := b.f("functionType")
+	if err != nil {
+		return nil, err
+	}
+
+	var params []interface{}
+	for _, param := range fn.Parameters {
+		v, err := b.ToTypescriptNode(param)
+		if err != nil {
+			return nil, fmt.Errorf("function parameter: %w", err)
+		}
+		params = append(params, v)
+	}
+
+	returnType, err := b.ToTypescriptNode(fn.Type)
+	if err != nil {
+		return nil, fmt.Errorf("function return type: %w", err)
+	}
+
+	res, err := functionF(goja.Undefined(), b.vm.NewArray(params...), returnType)
+	if err != nil {
+		return nil, xerrors.Errorf("call functionType: %w", err)
+	}
+	return res.ToObject(b.vm), nil
+}
+
+func (b *Bindings) Parameter(param *Parameter) (*goja.Object, error) {
+	paramF, err := b.f("parameter")
+	if err != nil {
+		return nil, err
+	}
+
+	// The type of arrow function parameters can be inferred.
+	var paramType goja.Value = goja.Undefined()
+	if param.Type != nil {
+		paramType, err = b.ToTypescriptNode(param.Type)
+		if err != nil {
+			return nil, fmt.Errorf("parameter %q type: %w", param.Name, err)
+		}
+	}
+
+	res, err := paramF(goja.Undefined(), b.vm.ToValue(param.Name), paramType, b.vm.ToValue(param.Rest), b.vm.ToValue(param.QuestionToken))
+	if err != nil {
+		return nil, xerrors.Errorf("call parameter: %w", err)
+	}
+	return res.ToObject(b.vm), nil
+}
+
+func (b *Bindings) MethodSignature(sig *MethodSignature) (*goja.Object, error) {
+	methodF, err := b.f("methodSignature")
+	if err != nil {
+		return nil, err
+	}
+
+	var params []interface{}
+	for _, param := range sig.Parameters {
+		v, err := b.ToTypescriptNode(param)
+		if err != nil {
+			return nil, fmt.Errorf("method %q parameter: %w", sig.Name, err)
+		}
+		params = append(params, v)
+	}
+
+	returnType, err := b.ToTypescriptNode(sig.Type)
+	if err != nil {
+		return nil, fmt.Errorf("method %q return type: %w", sig.Name, err)
+	}
+
+	res, err := methodF(goja.Undefined(),
+		b.vm.ToValue(sig.Name),
+		b.vm.ToValue(sig.QuestionToken),
+		b.vm.NewArray(params...),
+		returnType,
+	)
+	if err != nil {
+		return nil, xerrors.Errorf("call methodSignature: %w", err)
+	}
+	return res.ToObject(b.vm), nil
+}
+
 func (b *Bindings) Alias(alias *Alias) (*goja.Object, error) {
 	aliasFunc, err := b.f("aliasDecl")
 	if err != nil {
@@ -476,27 +677,95 @@ func (b *Bindings) FloatLiteral(value float64) (*goja.Object, error) {
 }
 
 func (b *Bindings) NumericLiteral(value int64) (*goja.Object, error) {
+	// The text is passed to keep the full precision of the value.
+	return b.numericLiteral(strconv.FormatInt(value, 10))
+}
+
+func (b *Bindings) numericLiteral(text string) (*goja.Object, error) {
 	literalF, err := b.f("numericLiteral")
 	if err != nil {
 		return nil, err
 	}
 
-	res, err := literalF(goja.Undefined(), b.vm.ToValue(value))
+	res, err := literalF(goja.Undefined(), b.vm.ToValue(text))
 	if err != nil {
 		return nil, xerrors.Errorf("call numericLiteral: %w", err)
 	}
 	return res.ToObject(b.vm), nil
 }
 
-func (b *Bindings) BooleanLiteral(value int) (*goja.Object, error) {
-	literalF, err := b.f("numericLiteral")
+func (b *Bindings) BigIntLiteral(value *BigIntLiteral) (*goja.Object, error) {
+	literalF, err := b.f("bigIntLiteral")
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := literalF(goja.Undefined(), b.vm.ToValue(value.Value))
+	if err != nil {
+		return nil, xerrors.Errorf("call bigIntLiteral: %w", err)
+	}
+	return res.ToObject(b.vm), nil
+}
+
+func (b *Bindings) PrefixUnary(value *PrefixUnaryExpression) (*goja.Object, error) {
+	prefixF, err := b.f("prefixUnaryExpression")
+	if err != nil {
+		return nil, err
+	}
+
+	operand, err := b.ToTypescriptNode(value.Operand)
+	if err != nil {
+		return nil, fmt.Errorf("prefix unary operand: %w", err)
+	}
+
+	res, err := prefixF(goja.Undefined(), b.vm.ToValue(value.Operator), operand)
+	if err != nil {
+		return nil, xerrors.Errorf("call prefixUnaryExpression: %w", err)
+	}
+	return res.ToObject(b.vm), nil
+}
+
+func (b *Bindings) TemplateLiteralType(value *TemplateLiteralType) (*goja.Object, error) {
+	templateF, err := b.f("templateLiteralType")
+	if err != nil {
+		return nil, err
+	}
+	spanF, err := b.f("templateLiteralTypeSpan")
+	if err != nil {
+		return nil, err
+	}
+
+	var spans []interface{}
+	for i, span := range value.Spans {
+		spanType, err := b.ToTypescriptNode(span.Type)
+		if err != nil {
+			return nil, fmt.Errorf("template literal span: %w", err)
+		}
+
+		tail := i == len(value.Spans)-1
+		res, err := spanF(goja.Undefined(), spanType, b.vm.ToValue(span.Literal), b.vm.ToValue(tail))
+		if err != nil {
+			return nil, xerrors.Errorf("call templateLiteralTypeSpan: %w", err)
+		}
+		spans = append(spans, res)
+	}
+
+	res, err := templateF(goja.Undefined(), b.vm.ToValue(value.Head), b.vm.NewArray(spans...))
+	if err != nil {
+		return nil, xerrors.Errorf("call templateLiteralType: %w", err)
+	}
+	return res.ToObject(b.vm), nil
+}
+
+func (b *Bindings) BooleanLiteral(value bool) (*goja.Object, error) {
+	literalF, err := b.f("booleanLiteral")
 	if err != nil {
 		return nil, err
 	}
 
 	res, err := literalF(goja.Undefined(), b.vm.ToValue(value))
 	if err != nil {
-		return nil, xerrors.Errorf("call numericLiteral: %w", err)
+		return nil, xerrors.Errorf("call booleanLiteral: %w", err)
 	}
 	return res.ToObject(b.vm), nil
 }
@@ -523,6 +792,55 @@ func (b *Bindings) ArrayLiteral(value *ArrayLiteralType) (*goja.Object, error) {
 	return res.ToObject(b.vm), nil
 }
 
+func (b *Bindings) ObjectLiteral(value *ObjectLiteral) (*goja.Object, error) {
+	literalF, err := b.f("objectLiteral")
+	if err != nil {
+		return nil, err
+	}
+
+	var properties []interface{}
+	for _, prop := range value.Properties {
+		v, err := b.ToTypescriptNode(prop)
+		if err != nil {
+			return nil, fmt.Errorf("object literal property: %w", err)
+		}
+		properties = append(properties, v)
+	}
+
+	var spreads []interface{}
+	for _, spread := range value.Spreads {
+		v, err := b.ToTypescriptNode(spread)
+		if err != nil {
+			return nil, fmt.Errorf("object literal spread: %w", err)
+		}
+		spreads = append(spreads, v)
+	}
+
+	res, err := literalF(goja.Undefined(), b.vm.NewArray(properties...), b.vm.NewArray(spreads...))
+	if err != nil {
+		return nil, xerrors.Errorf("call objectLiteral: %w", err)
+	}
+	return res.ToObject(b.vm), nil
+}
+
+func (b *Bindings) PropertyAssignment(prop *PropertyAssignment) (*goja.Object, error) {
+	assignF, err := b.f("propertyAssignment")
+	if err != nil {
+		return nil, err
+	}
+
+	init, err := b.ToTypescriptNode(prop.Initializer)
+	if err != nil {
+		return nil, fmt.Errorf("property %q initializer: %w", prop.Name, err)
+	}
+
+	res, err := assignF(goja.Undefined(), b.vm.ToValue(prop.Name), init)
+	if err != nil {
+		return nil, xerrors.Errorf("call propertyAssignment: %w", err)
+	}
+	return res.ToObject(b.vm), nil
+}
+
 func (b *Bindings) VariableStatement(stmt *VariableStatement) (*goja.Object, error) {
 	aliasFunc, err := b.f("variableStatement")
 	if err != nil {
@@ -650,6 +968,10 @@ func (b *Bindings) EnumMember(value *EnumMember) (*goja.Object, error) {
 }
 
 func (b *Bindings) EnumDeclaration(e *Enum) (*goja.Object, error) {
+	if e.Object {
+		return b.objectEnumDeclaration(e)
+	}
+
 	aliasFunc, err := b.f("enumDeclaration")
 	if err != nil {
 		return nil, err
@@ -668,6 +990,7 @@ func (b *Bindings) EnumDeclaration(e *Enum) (*goja.Object, error) {
 		b.vm.ToValue(ToStrings(e.Modifiers)),
 		b.vm.ToValue(e.Name.Ref()),
 		b.vm.NewArray(members...),
+		b.vm.ToValue(e.Const),
 	)
 	if err != nil {
 		return nil, xerrors.Errorf("call enumDeclaration: %w", err)
@@ -678,6 +1001,55 @@ func (b *Bindings) EnumDeclaration(e *Enum) (*goja.Object, error) {
 	return obj, nil
 }
 
+// objectEnumDeclaration returns the object of the members, and the type of
+// its values, as an array of both statements.
+func (b *Bindings) objectEnumDeclaration(e *Enum) (*goja.Object, error) {
+	object := &ObjectLiteral{
+		Properties: make([]*PropertyAssignment, 0, len(e.Members)),
+	}
+	for _, member := range e.Members {
+		if member.Value == nil {
+			return nil, fmt.Errorf("object enum member %q has no value", member.Name)
+		}
+		prop := &PropertyAssignment{
+			Name:        member.Name,
+			Initializer: member.Value,
+		}
+		prop.AppendComments(member.Comments())
+		object.Properties = append(object.Properties, prop)
+	}
+
+	stmt, err := b.ToTypescriptNode(&VariableStatement{
+		Modifiers: e.Modifiers,
+		Declarations: &VariableDeclarationList{
+			Declarations: []*VariableDeclaration{
+				{
+					Name:        e.Name,
+					Initializer: &AsConstExpression{Expression: object},
+				},
+			},
+			Flags: NodeFlagsConstant,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("enum object: %w", err)
+	}
+
+	alias, err := b.ToTypescriptNode(&Alias{
+		Name:      e.Name,
+		Modifiers: e.Modifiers,
+		Type: &IndexedAccessType{
+			Object: &TypeQuery{Name: e.Name},
+			Index:  OperatorNode(KeywordKeyOf, &TypeQuery{Name: e.Name}),
+		},
+		Parameters: []*TypeParameter{},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("enum type: %w", err)
+	}
+	return b.vm.NewArray(stmt, alias), nil
+}
+
 func (b *Bindings) TypeLiteralNode(node *TypeLiteralNode) (*goja.Object, error) {
 	typeLiteralF, err := b.f("typeLiteralNode")
 	if err != nil {
@@ -694,6 +1066,15 @@ func (b *Bindings) TypeLiteralNode(node *TypeLiteralNode) (*goja.Object, error)
 		members = append(members, v)
 	}
 
+	for _, method := range node.Methods {
+		v, err := b.ToTypescriptNode(method)
+		if err != nil {
+			return nil, err
+		}
+
+		members = append(members, v)
+	}
+
 	res, err := typeLiteralF(goja.Undefined(), b.vm.NewArray(members...))
 	if err != nil {
 		return nil, xerrors.Errorf("call typeLiteralNode: %w", err)
@@ -724,11 +1105,317 @@ func (b *Bindings) TypeIntersection(node *TypeIntersection) (*goja.Object, error
 	return res.ToObject(b.vm), nil
 }
 
+func (b *Bindings) TypeQuery(node *TypeQuery) (*goja.Object, error) {
+	queryF, err := b.f("typeQuery")
+	if err != nil {
+		return nil, err
+	}
+
+	var name goja.Value = b.vm.ToValue(node.Name.Ref())
+	if node.Name.Namespace != "" && node.Name.Namespace != b.namespace {
+		name, err = b.QualifiedName(node.Name.Namespace, node.Name.Ref())
+		if err != nil {
+			return nil, fmt.Errorf("type query qualified name: %w", err)
+		}
+	}
+
+	res, err := queryF(goja.Undefined(), name)
+	if err != nil {
+		return nil, xerrors.Errorf("call typeQuery: %w", err)
+	}
+	return res.ToObject(b.vm), nil
+}
+
+func (b *Bindings) IndexedAccessType(node *IndexedAccessType) (*goja.Object, error) {
+	accessF, err := b.f("indexedAccessType")
+	if err != nil {
+		return nil, err
+	}
+
+	object, err := b.ToTypescriptNode(node.Object)
+	if err != nil {
+		return nil, fmt.Errorf("indexed access object: %w", err)
+	}
+	index, err := b.ToTypescriptNode(node.Index)
+	if err != nil {
+		return nil, fmt.Errorf("indexed access index: %w", err)
+	}
+
+	res, err := accessF(goja.Undefined(), object, index)
+	if err != nil {
+		return nil, xerrors.Errorf("call indexedAccessType: %w", err)
+	}
+	return res.ToObject(b.vm), nil
+}
+
+func (b *Bindings) AsConst(node *AsConstExpression) (*goja.Object, error) {
+	asConstF, err := b.f("asConst")
+	if err != nil {
+		return nil, err
+	}
+
+	expr, err := b.ToTypescriptNode(node.Expression)
+	if err != nil {
+		return nil, fmt.Errorf("as const expression: %w", err)
+	}
+
+	res, err := asConstF(goja.Undefined(), expr)
+	if err != nil {
+		return nil, xerrors.Errorf("call asConst: %w", err)
+	}
+	return res.ToObject(b.vm), nil
+}
+
+func (b *Bindings) PropertyAccess(node *PropertyAccess) (*goja.Object, error) {
+	accessF, err := b.f("propertyAccess")
+	if err != nil {
+		return nil, err
+	}
+
+	namespace := ""
+	if node.Object.Namespace != b.namespace {
+		namespace = node.Object.Namespace
+	}
+
+	res, err := accessF(goja.Undefined(),
+		b.vm.ToValue(namespace),
+		b.vm.ToValue(node.Object.Ref()),
+		b.vm.ToValue(node.Name),
+	)
+	if err != nil {
+		return nil, xerrors.Errorf("call propertyAccess: %w", err)
+	}
+	return res.ToObject(b.vm), nil
+}
+
+func (b *Bindings) IdentifierExpression(node *IdentifierExpression) (*goja.Object, error) {
+	identF, err := b.f("identifierExpression")
+	if err != nil {
+		return nil, err
+	}
+
+	namespace := ""
+	if node.Name.Namespace != b.namespace {
+		namespace = node.Name.Namespace
+	}
+
+	res, err := identF(goja.Undefined(), b.vm.ToValue(namespace), b.vm.ToValue(node.Name.Ref()))
+	if err != nil {
+		return nil, xerrors.Errorf("call identifierExpression: %w", err)
+	}
+	return res.ToObject(b.vm), nil
+}
+
+func (b *Bindings) MemberAccess(node *MemberAccess) (*goja.Object, error) {
+	accessF, err := b.f("memberAccess")
+	if err != nil {
+		return nil, err
+	}
+
+	expr, err := b.ToTypescriptNode(node.Expression)
+	if err != nil {
+		return nil, fmt.Errorf("member access %q: %w", node.Name, err)
+	}
+
+	res, err := accessF(goja.Undefined(), expr, b.vm.ToValue(node.Name))
+	if err != nil {
+		return nil, xerrors.Errorf("call memberAccess: %w", err)
+	}
+	return res.ToObject(b.vm), nil
+}
+
+func (b *Bindings) CallExpression(node *CallExpression) (*goja.Object, error) {
+	callF, err := b.f("callExpression")
+	if err != nil {
+		return nil, err
+	}
+
+	expr, err := b.ToTypescriptNode(node.Expression)
+	if err != nil {
+		return nil, fmt.Errorf("call expression: %w", err)
+	}
+
+	var args []interface{}
+	for _, arg := range node.Arguments {
+		v, err := b.ToTypescriptNode(arg)
+		if err != nil {
+			return nil, fmt.Errorf("call argument: %w", err)
+		}
+		args = append(args, v)
+	}
+
+	res, err := callF(goja.Undefined(), expr, b.vm.NewArray(args...), b.vm.ToValue(node.New))
+	if err != nil {
+		return nil, xerrors.Errorf("call callExpression: %w", err)
+	}
+	return res.ToObject(b.vm), nil
+}
+
+func (b *Bindings) ArrowFunction(node *ArrowFunction) (*goja.Object, error) {
+	arrowF, err := b.f("arrowFunction")
+	if err != nil {
+		return nil, err
+	}
+
+	var params []interface{}
+	for _, param := range node.Parameters {
+		v, err := b.ToTypescriptNode(param)
+		if err != nil {
+			return nil, fmt.Errorf("arrow function parameter: %w", err)
+		}
+		params = append(params, v)
+	}
+
+	body, err := b.ToTypescriptNode(node.Body)
+	if err != nil {
+		return nil, fmt.Errorf("arrow function body: %w", err)
+	}
+
+	res, err := arrowF(goja.Undefined(), b.vm.NewArray(params...), body)
+	if err != nil {
+		return nil, xerrors.Errorf("call arrowFunction: %w", err)
+	}
+	return res.ToObject(b.vm), nil
+}
+
+func (b *Bindings) ConditionalExpression(node *ConditionalExpression) (*goja.Object, error) {
+	conditionalF, err := b.f("conditionalExpression")
+	if err != nil {
+		return nil, err
+	}
+
+	condition, err := b.ToTypescriptNode(node.Condition)
+	if err != nil {
+		return nil, fmt.Errorf("conditional condition: %w", err)
+	}
+	whenTrue, err := b.ToTypescriptNode(node.WhenTrue)
+	if err != nil {
+		return nil, fmt.Errorf("conditional when true: %w", err)
+	}
+	whenFalse, err := b.ToTypescriptNode(node.WhenFalse)
+	if err != nil {
+		return nil, fmt.Errorf("conditional when false: %w", err)
+	}
+
+	res, err := conditionalF(goja.Undefined(), condition, whenTrue, whenFalse)
+	if err != nil {
+		return nil, xerrors.Errorf("call conditionalExpression: %w", err)
+	}
+	return res.ToObject(b.vm), nil
+}
+
+func (b *Bindings) BinaryExpression(node *BinaryExpression) (*goja.Object, error) {
+	binaryF, err := b.f("binaryExpression")
+	if err != nil {
+		return nil, err
+	}
+
+	left, err := b.ToTypescriptNode(node.Left)
+	if err != nil {
+		return nil, fmt.Errorf("binary left: %w", err)
+	}
+	right, err := b.ToTypescriptNode(node.Right)
+	if err != nil {
+		return nil, fmt.Errorf("binary right: %w", err)
+	}
+
+	res, err := binaryF(goja.Undefined(), left, b.vm.ToValue(node.Operator), right)
+	if err != nil {
+		return nil, xerrors.Errorf("call binaryExpression: %w", err)
+	}
+	return res.ToObject(b.vm), nil
+}
+
+func (b *Bindings) Namespace(ns *Namespace) (*goja.Object, error) {
+	namespaceF, err := b.f("namespaceDecl")
+	if err != nil {
+		return nil, err
+	}
+
+	// References within the namespace do not need to be qualified.
+	parent := b.namespace
+	b.namespace = ns.Name
+	defer func() { b.namespace = parent }()
+
+	var statements []interface{}
+	for _, stmt := range ns.Statements {
+		v, err := b.ToTypescriptNode(stmt)
+		if err != nil {
+			return nil, fmt.Errorf("namespace statement: %w", err)
+		}
+		statements = append(statements, v)
+	}
+
+	res, err := namespaceF(goja.Undefined(),
+		b.vm.ToValue(ToStrings(ns.Modifiers)),
+		b.vm.ToValue(ns.Name),
+		b.vm.NewArray(statements...),
+		b.vm.ToValue(ns.Global),
+	)
+	if err != nil {
+		return nil, xerrors.Errorf("call namespaceDecl: %w", err)
+	}
+	return res.ToObject(b.vm), nil
+}
+
+func (b *Bindings) FunctionDeclaration(fn *FunctionDeclaration) (*goja.Object, error) {
+	functionF, err := b.f("functionDecl")
+	if err != nil {
+		return nil, err
+	}
+
+	var params []interface{}
+	for _, param := range fn.Parameters {
+		v, err := b.ToTypescriptNode(param)
+		if err != nil {
+			return nil, fmt.Errorf("function %q parameter: %w", fn.Name.Ref(), err)
+		}
+		params = append(params, v)
+	}
+
+	returnType, err := b.ToTypescriptNode(fn.Type)
+	if err != nil {
+		return nil, fmt.Errorf("function %q return type: %w", fn.Name.Ref(), err)
+	}
+
+	var body goja.Value = goja.Undefined()
+	if fn.Body != nil {
+		body, err = b.ToTypescriptNode(fn.Body)
+		if err != nil {
+			return nil, fmt.Errorf("function %q body: %w", fn.Name.Ref(), err)
+		}
+	}
+
+	res, err := functionF(goja.Undefined(),
+		b.vm.ToValue(ToStrings(fn.Modifiers)),
+		b.vm.ToValue(fn.Name.Ref()),
+		b.vm.NewArray(params...),
+		returnType,
+		body,
+	)
+	if err != nil {
+		return nil, xerrors.Errorf("call functionDecl: %w", err)
+	}
+	return res.ToObject(b.vm), nil
+}
+
 func (b *Bindings) CommentGojaObject(comments []SyntheticComment, object *goja.Object) (*goja.Object, error) {
 	if len(comments) == 0 {
 		return object, nil
 	}
 
+	if object.ClassName() == "Array" {
+		// Declarations of multiple statements are commented on the first.
+		first, err := b.CommentGojaObject(comments, object.Get("0").ToObject(b.vm))
+		if err != nil {
+			return nil, err
+		}
+		if err := object.Set("0", first); err != nil {
+			return nil, xerrors.Errorf("set commented statement: %w", err)
+		}
+		return object, nil
+	}
+
 	commentF, err := b.f("addSyntheticComment")
 	if err != nil {
 		return nil, err
@@ -792,9 +1479,18 @@ func (b *Bindings) CommentGojaObject(comments []SyntheticComment, object *goja.O
 }
 
 func convertDeprecation(txt string) string {
-	if len(txt) > 13 && txt[:13] == " Deprecated: " {
-		return " @deprecated " + txt[13:]
+	if notice, ok := DeprecationNotice(txt); ok {
+		return " @deprecated " + notice
 	}
 
 	return txt
 }
+
+// DeprecationNotice returns the text after the golang 'Deprecated: ' marker,
+// if the comment text is a deprecation notice.
+func DeprecationNotice(txt string) (string, bool) {
+	if len(txt) > 13 && txt[:13] == " Deprecated: " {
+		return txt[13:], true
+	}
+	return "", false
+}
@@ -0,0 +1,264 @@
+package bindings
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// Clone returns a deep copy of the node. The copy shares no mutable state
+// with the original, so either can be mutated without affecting the other.
+// Comments and source locations are copied as well.
+// Golang type information, such as an Identifier's package, is not copied.
+func Clone[N Node](node N) N {
+	c, _ := cloneNode(node).(N)
+	return c
+}
+
+func cloneNode(node Node) Node {
+	// Typed nil pointers are returned as is.
+	if v := reflect.ValueOf(node); !v.IsValid() || (v.Kind() == reflect.Pointer && v.IsNil()) {
+		return node
+	}
+
+	// If there is a missing node, please add it.
+	switch n := node.(type) {
+	case *LiteralKeyword:
+		cpy := *n
+		return &cpy
+	case *LiteralType:
+		cpy := *n
+		return &cpy
+	case *Null:
+		return &Null{}
+	case *ReferenceType:
+		return &ReferenceType{
+			Name:      n.Name,
+			Arguments: cloneList(n.Arguments),
+		}
+	case *TupleType:
+		return &TupleType{
+			Node:     Clone(n.Node),
+			Length:   n.Length,
+			Elements: cloneList(n.Elements),
+		}
+	case *FunctionType:
+		return &FunctionType{
+			Parameters: cloneList(n.Parameters),
+			Type:       Clone(n.Type),
+		}
+	case *Parameter:
+		cpy := *n
+		cpy.Type = Clone(n.Type)
+		return &cpy
+	case *ArrayType:
+		return &ArrayType{Node: Clone(n.Node)}
+	case *ArrayLiteralType:
+		return &ArrayLiteralType{Elements: cloneList(n.Elements)}
+	case *ObjectLiteral:
+		return &ObjectLiteral{
+			Spreads:    cloneList(n.Spreads),
+			Properties: cloneList(n.Properties),
+		}
+	case *PropertyAssignment:
+		return &PropertyAssignment{
+			Name:            n.Name,
+			Initializer:     Clone(n.Initializer),
+			SupportComments: n.SupportComments.clone(),
+		}
+	case *PrefixUnaryExpression:
+		return &PrefixUnaryExpression{
+			Operator: n.Operator,
+			Operand:  Clone(n.Operand),
+		}
+	case *BigIntLiteral:
+		cpy := *n
+		return &cpy
+	case *TemplateLiteralType:
+		return &TemplateLiteralType{
+			Head:  n.Head,
+			Spans: cloneList(n.Spans),
+		}
+	case *TemplateLiteralTypeSpan:
+		return &TemplateLiteralTypeSpan{
+			Type:    Clone(n.Type),
+			Literal: n.Literal,
+		}
+	case *UnionType:
+		return &UnionType{Types: cloneList(n.Types)}
+	case *TypeIntersection:
+		return &TypeIntersection{Types: cloneList(n.Types)}
+	case *TypeQuery:
+		return &TypeQuery{Name: n.Name}
+	case *IndexedAccessType:
+		return &IndexedAccessType{
+			Object: Clone(n.Object),
+			Index:  Clone(n.Index),
+		}
+	case *AsConstExpression:
+		return &AsConstExpression{Expression: Clone(n.Expression)}
+	case *PropertyAccess:
+		return &PropertyAccess{Object: n.Object, Name: n.Name}
+	case *IdentifierExpression:
+		return &IdentifierExpression{Name: n.Name}
+	case *MemberAccess:
+		return &MemberAccess{Expression: Clone(n.Expression), Name: n.Name}
+	case *CallExpression:
+		return &CallExpression{
+			Expression: Clone(n.Expression),
+			Arguments:  cloneList(n.Arguments),
+			New:        n.New,
+		}
+	case *ArrowFunction:
+		return &ArrowFunction{
+			Parameters: cloneList(n.Parameters),
+			Body:       Clone(n.Body),
+		}
+	case *ConditionalExpression:
+		return &ConditionalExpression{
+			Condition: Clone(n.Condition),
+			WhenTrue:  Clone(n.WhenTrue),
+			WhenFalse: Clone(n.WhenFalse),
+		}
+	case *BinaryExpression:
+		return &BinaryExpression{
+			Left:     Clone(n.Left),
+			Operator: n.Operator,
+			Right:    Clone(n.Right),
+		}
+	case *ExpressionWithTypeArguments:
+		return &ExpressionWithTypeArguments{
+			Expression: Clone(n.Expression),
+			Arguments:  cloneList(n.Arguments),
+		}
+	case *VariableDeclarationList:
+		return &VariableDeclarationList{
+			Declarations: cloneList(n.Declarations),
+			Flags:        n.Flags,
+		}
+	case *VariableDeclaration:
+		return &VariableDeclaration{
+			Name:            n.Name,
+			ExclamationMark: n.ExclamationMark,
+			Type:            Clone(n.Type),
+			Initializer:     Clone(n.Initializer),
+		}
+	case *OperatorNodeType:
+		return &OperatorNodeType{
+			Keyword: n.Keyword,
+			Type:    Clone(n.Type),
+		}
+	case *EnumMember:
+		return &EnumMember{
+			Name:            n.Name,
+			Value:           Clone(n.Value),
+			SupportComments: n.SupportComments.clone(),
+		}
+	case *TypeLiteralNode:
+		return &TypeLiteralNode{
+			Members: cloneList(n.Members),
+			Methods: cloneList(n.Methods),
+		}
+	case *HeritageClause:
+		return &HeritageClause{
+			Token: n.Token,
+			Args:  cloneList(n.Args),
+		}
+	case *Interface:
+		return &Interface{
+			Name:            n.Name,
+			Modifiers:       slices.Clone(n.Modifiers),
+			Fields:          cloneList(n.Fields),
+			Methods:         cloneList(n.Methods),
+			Parameters:      cloneList(n.Parameters),
+			Heritage:        cloneList(n.Heritage),
+			SupportComments: n.SupportComments.clone(),
+			Source:          n.Source,
+		}
+	case *PropertySignature:
+		return &PropertySignature{
+			Name:            n.Name,
+			Modifiers:       slices.Clone(n.Modifiers),
+			QuestionToken:   n.QuestionToken,
+			Type:            Clone(n.Type),
+			Constraints:     n.Constraints.clone(),
+			SupportComments: n.SupportComments.clone(),
+		}
+	case *MethodSignature:
+		return &MethodSignature{
+			Name:            n.Name,
+			QuestionToken:   n.QuestionToken,
+			Parameters:      cloneList(n.Parameters),
+			Type:            Clone(n.Type),
+			SupportComments: n.SupportComments.clone(),
+		}
+	case *Alias:
+		return &Alias{
+			Name:            n.Name,
+			Modifiers:       slices.Clone(n.Modifiers),
+			Type:            Clone(n.Type),
+			Parameters:      cloneList(n.Parameters),
+			SupportComments: n.SupportComments.clone(),
+			Source:          n.Source,
+		}
+	case *TypeParameter:
+		return &TypeParameter{
+			Name:        n.Name,
+			Modifiers:   slices.Clone(n.Modifiers),
+			Type:        Clone(n.Type),
+			DefaultType: Clone(n.DefaultType),
+		}
+	case *VariableStatement:
+		return &VariableStatement{
+			Modifiers:       slices.Clone(n.Modifiers),
+			Declarations:    Clone(n.Declarations),
+			Source:          n.Source,
+			SupportComments: n.SupportComments.clone(),
+		}
+	case *Enum:
+		return &Enum{
+			Name:            n.Name,
+			Modifiers:       slices.Clone(n.Modifiers),
+			Const:           n.Const,
+			Object:          n.Object,
+			Members:         cloneList(n.Members),
+			SupportComments: n.SupportComments.clone(),
+			Source:          n.Source,
+		}
+	case *Namespace:
+		return &Namespace{
+			Name:            n.Name,
+			Modifiers:       slices.Clone(n.Modifiers),
+			Statements:      cloneList(n.Statements),
+			Global:          n.Global,
+			SupportComments: n.SupportComments.clone(),
+		}
+	case *FunctionDeclaration:
+		return &FunctionDeclaration{
+			Name:            n.Name,
+			Modifiers:       slices.Clone(n.Modifiers),
+			Parameters:      cloneList(n.Parameters),
+			Type:            Clone(n.Type),
+			Body:            Clone(n.Body),
+			SupportComments: n.SupportComments.clone(),
+			Source:          n.Source,
+		}
+	default:
+		panic(fmt.Sprintf("bindings.Clone: unexpected node type %T", n))
+	}
+}
+
+func cloneList[N Node](list []N) []N {
+	if list == nil {
+		return nil
+	}
+	cpy := make([]N, 0, len(list))
+	for _, n := range list {
+		cpy = append(cpy, Clone(n))
+	}
+	return cpy
+}
+
+func (s SupportComments) clone() SupportComments {
+	return SupportComments{comments: slices.Clone(s.comments)}
+}
@@ -0,0 +1,81 @@
+package bindings_test
+
+import (
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/guts/bindings"
+)
+
+func TestCloneEqual(t *testing.T) {
+	t.Parallel()
+
+	str := bindings.LiteralKeyword(bindings.KeywordString)
+	num := bindings.LiteralKeyword(bindings.KeywordNumber)
+	field := &bindings.PropertySignature{
+		Name:      "names",
+		Modifiers: []bindings.Modifier{bindings.ModifierReadonly},
+		Type: bindings.Union(
+			bindings.Array(&str),
+			bindings.HomogeneousTuple(2, &num),
+			&bindings.Null{},
+		),
+	}
+	field.AppendComment(bindings.SyntheticComment{Leading: true, Text: "names of things"})
+
+	intf := &bindings.Interface{
+		Name:      bindings.Identifier{Name: "Foo", Prefix: "Prefix"},
+		Modifiers: []bindings.Modifier{bindings.ModifierExport},
+		Fields: []*bindings.PropertySignature{
+			field,
+			{
+				Name: "literal",
+				Type: &bindings.TypeLiteralNode{
+					Members: []*bindings.PropertySignature{
+						{Name: "inner", Type: bindings.OperatorNode(bindings.KeywordReadonly, bindings.Array(&str))},
+					},
+				},
+			},
+		},
+		Parameters: []*bindings.TypeParameter{
+			{Name: bindings.Identifier{Name: "T"}, Type: &str},
+		},
+		Heritage: []*bindings.HeritageClause{
+			bindings.HeritageClauseExtends(bindings.Reference(bindings.Identifier{Name: "Bar"}, &str)),
+		},
+		Source: bindings.Source{
+			File:     "foo.go",
+			Position: token.Position{Filename: "foo.go", Line: 10},
+		},
+	}
+
+	cpy := bindings.Clone(intf)
+	require.NotSame(t, intf, cpy)
+	require.True(t, bindings.Equal(intf, cpy))
+
+	// Mutating the copy does not change the original.
+	cpy.Fields[0].Type.(*bindings.UnionType).Types[0].(*bindings.ArrayType).Node = &num
+	require.Equal(t, &str, intf.Fields[0].Type.(*bindings.UnionType).Types[0].(*bindings.ArrayType).Node)
+	require.False(t, bindings.Equal(intf, cpy))
+
+	// Comments are compared
+	cpy = bindings.Clone(intf)
+	cpy.Fields[0].AppendComment(bindings.SyntheticComment{Text: "another"})
+	require.Len(t, intf.Fields[0].Comments(), 1)
+	require.False(t, bindings.Equal(intf, cpy))
+
+	// Sources are compared
+	cpy = bindings.Clone(intf)
+	cpy.Source.Position.Line = 11
+	require.False(t, bindings.Equal(intf, cpy))
+
+	// Nil handling
+	var nilExpr bindings.ExpressionType
+	require.Nil(t, bindings.Clone(nilExpr))
+	require.True(t, bindings.Equal(nil, nilExpr))
+	require.False(t, bindings.Equal(nil, &str))
+	require.False(t, bindings.Equal(&str, &num))
+	require.False(t, bindings.Equal(&str, bindings.Array(&str)))
+}
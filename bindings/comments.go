@@ -40,6 +40,21 @@ func (s *SupportComments) LeadingComment(text string) {
 	})
 }
 
+// JSDocTag adds a JSDoc tag, like '@throws', that is included in the JSDoc
+// block of the node.
+func (s *SupportComments) JSDocTag(tag string) {
+	s.AppendComment(jsDocTagComment(tag))
+}
+
+func jsDocTagComment(tag string) SyntheticComment {
+	return SyntheticComment{
+		Leading:         true,
+		SingleLine:      true,
+		Text:            " " + tag,
+		TrailingNewLine: true,
+	}
+}
+
 func (s *SupportComments) AppendComments(comments []SyntheticComment) {
 	s.comments = append(s.comments, comments...)
 }
@@ -62,6 +77,16 @@ type Source struct {
 	Position token.Position
 }
 
+// Locatable is implemented by any node that embeds a Source.
+type Locatable interface {
+	Location() Source
+}
+
+// Location returns the golang source location of the node.
+func (s Source) Location() Source {
+	return s
+}
+
 // SourceComment returns a synthetic comment indicating the source file.
 // If the source file is empty, the second return is false.
 func (s Source) SourceComment() (SyntheticComment, bool) {
@@ -0,0 +1,154 @@
+package bindings
+
+import (
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Constraints are the validation rules of a property, parsed from struct tags
+// like `validate:"required,min=1,max=64"`. The rules are printed as JSDoc tags,
+// and are available to anything that generates schemas from the nodes.
+type Constraints struct {
+	// Required is the 'required' rule. The value must not be the zero value.
+	Required bool
+	// MinLength and MaxLength limit the length of strings.
+	MinLength *int
+	MaxLength *int
+	// MinItems and MaxItems limit the length of arrays.
+	MinItems *int
+	MaxItems *int
+	// Minimum and Maximum limit numbers, inclusive.
+	Minimum *float64
+	Maximum *float64
+	// ExclusiveMinimum and ExclusiveMaximum limit numbers, exclusive.
+	ExclusiveMinimum *float64
+	ExclusiveMaximum *float64
+	// Pattern is a regular expression that strings must match.
+	Pattern string
+	// Format is the JSON Schema format of strings, eg: 'email'.
+	Format string
+	// OneOf are the only allowed values. Values are strings or float64s.
+	OneOf []any
+}
+
+// IsEmpty returns true if there are no constraints.
+func (c *Constraints) IsEmpty() bool {
+	return c == nil || c.Equal(&Constraints{})
+}
+
+// Equal returns true if both sets of constraints are the same.
+func (c *Constraints) Equal(o *Constraints) bool {
+	if c == nil || o == nil {
+		return c == o
+	}
+	return c.Required == o.Required &&
+		equalPtr(c.MinLength, o.MinLength) &&
+		equalPtr(c.MaxLength, o.MaxLength) &&
+		equalPtr(c.MinItems, o.MinItems) &&
+		equalPtr(c.MaxItems, o.MaxItems) &&
+		equalPtr(c.Minimum, o.Minimum) &&
+		equalPtr(c.Maximum, o.Maximum) &&
+		equalPtr(c.ExclusiveMinimum, o.ExclusiveMinimum) &&
+		equalPtr(c.ExclusiveMaximum, o.ExclusiveMaximum) &&
+		c.Pattern == o.Pattern &&
+		c.Format == o.Format &&
+		slices.Equal(c.OneOf, o.OneOf)
+}
+
+func (c *Constraints) clone() *Constraints {
+	if c == nil {
+		return nil
+	}
+	return &Constraints{
+		Required:         c.Required,
+		MinLength:        clonePtr(c.MinLength),
+		MaxLength:        clonePtr(c.MaxLength),
+		MinItems:         clonePtr(c.MinItems),
+		MaxItems:         clonePtr(c.MaxItems),
+		Minimum:          clonePtr(c.Minimum),
+		Maximum:          clonePtr(c.Maximum),
+		ExclusiveMinimum: clonePtr(c.ExclusiveMinimum),
+		ExclusiveMaximum: clonePtr(c.ExclusiveMaximum),
+		Pattern:          c.Pattern,
+		Format:           c.Format,
+		OneOf:            slices.Clone(c.OneOf),
+	}
+}
+
+// JSDoc returns the constraints as JSDoc tags.
+// 'Required' is not included, as the property type already says if it is
+// optional. Values are escaped, so a '*/' cannot end the comment.
+func (c *Constraints) JSDoc() []string {
+	if c == nil {
+		return nil
+	}
+
+	var tags []string
+	integer := func(tag string, v *int) {
+		if v != nil {
+			tags = append(tags, tag+" "+strconv.Itoa(*v))
+		}
+	}
+	number := func(tag string, v *float64) {
+		if v != nil {
+			tags = append(tags, tag+" "+strconv.FormatFloat(*v, 'f', -1, 64))
+		}
+	}
+
+	integer("@minLength", c.MinLength)
+	integer("@maxLength", c.MaxLength)
+	integer("@minItems", c.MinItems)
+	integer("@maxItems", c.MaxItems)
+	number("@minimum", c.Minimum)
+	number("@maximum", c.Maximum)
+	number("@exclusiveMinimum", c.ExclusiveMinimum)
+	number("@exclusiveMaximum", c.ExclusiveMaximum)
+	if c.Pattern != "" {
+		tags = append(tags, "@pattern "+escapeComment(c.Pattern))
+	}
+	if c.Format != "" {
+		tags = append(tags, "@format "+escapeComment(c.Format))
+	}
+	if len(c.OneOf) > 0 {
+		values, err := json.Marshal(c.OneOf)
+		if err == nil {
+			tags = append(tags, "@oneOf "+escapeComment(string(values)))
+		}
+	}
+	return tags
+}
+
+// escapeComment escapes the end of a block comment in a value.
+//
+//	^a*/b$ -> ^a*\/b$
+func escapeComment(value string) string {
+	return strings.ReplaceAll(value, "*/", `*\/`)
+}
+
+// comments are the JSDoc tags as comments, so they are included in the JSDoc
+// block of the property.
+func (c *Constraints) comments() []SyntheticComment {
+	tags := c.JSDoc()
+	comments := make([]SyntheticComment, 0, len(tags))
+	for _, tag := range tags {
+		comments = append(comments, jsDocTagComment(tag))
+	}
+	return comments
+}
+
+func clonePtr[T any](v *T) *T {
+	if v == nil {
+		return nil
+	}
+	cpy := *v
+	return &cpy
+}
+
+func equalPtr[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -11,6 +11,7 @@ type Interface struct {
 	Name       Identifier
 	Modifiers  []Modifier
 	Fields     []*PropertySignature
+	Methods    []*MethodSignature
 	Parameters []*TypeParameter
 	Heritage   []*HeritageClause
 	SupportComments
@@ -27,11 +28,26 @@ type PropertySignature struct {
 	Modifiers     []Modifier
 	QuestionToken bool
 	Type          ExpressionType
+	// Constraints are the validation rules from the struct tags, if any.
+	Constraints *Constraints
 	SupportComments
 }
 
 func (*PropertySignature) isNode() {}
 
+// MethodSignature is a method in an interface
+// Get(key string) (Value, error) -> Get(key: string): Value
+type MethodSignature struct {
+	Name          string
+	QuestionToken bool
+	Parameters    []*Parameter
+	// Type is the return type
+	Type ExpressionType
+	SupportComments
+}
+
+func (*MethodSignature) isNode() {}
+
 type Alias struct {
 	Name       Identifier
 	Modifiers  []Modifier
@@ -114,10 +130,49 @@ func (*VariableStatement) isDeclarationType() {}
 type Enum struct {
 	Name      Identifier
 	Modifiers []Modifier
-	Members   []*EnumMember
+	// Const enums are inlined by the compiler, and have no runtime object.
+	// const enum Foo { ... }
+	Const bool
+	// Object enums are a readonly object of the members, and a type of the
+	// member values. Every member must have a value. Const is ignored.
+	// const Foo = { ... } as const; type Foo = (typeof Foo)[keyof typeof Foo]
+	Object  bool
+	Members []*EnumMember
 	SupportComments
 	Source
 }
 
 func (*Enum) isNode()            {}
 func (*Enum) isDeclarationType() {}
+
+// Namespace groups declarations into a typescript namespace.
+// export namespace pkg { ... }
+type Namespace struct {
+	Name       string
+	Modifiers  []Modifier
+	Statements []DeclarationType
+	// Global declares the statements in the global scope instead.
+	// declare global { ... }
+	Global bool
+	SupportComments
+}
+
+func (*Namespace) isNode()            {}
+func (*Namespace) isDeclarationType() {}
+
+// FunctionDeclaration is a function. Without a body, it is only a declaration.
+// function name(a: string): number;
+type FunctionDeclaration struct {
+	Name       Identifier
+	Modifiers  []Modifier
+	Parameters []*Parameter
+	// Type is the return type
+	Type ExpressionType
+	// Body is the returned expression, if set.
+	Body ExpressionType
+	SupportComments
+	Source
+}
+
+func (*FunctionDeclaration) isNode()            {}
+func (*FunctionDeclaration) isDeclarationType() {}
@@ -0,0 +1,239 @@
+package bindings
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// Equal reports whether two nodes are structurally equal. Comments and
+// source locations are compared as well. Identifiers are equal if they have
+// the same name, prefix, namespace and golang package path.
+func Equal(a, b Node) bool {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	nilA := !va.IsValid() || (va.Kind() == reflect.Pointer && va.IsNil())
+	nilB := !vb.IsValid() || (vb.Kind() == reflect.Pointer && vb.IsNil())
+	if nilA || nilB {
+		return nilA == nilB
+	}
+	if va.Type() != vb.Type() {
+		return false
+	}
+
+	// If there is a missing node, please add it.
+	switch a := a.(type) {
+	case *LiteralKeyword:
+		return *a == *b.(*LiteralKeyword)
+	case *LiteralType:
+		return reflect.DeepEqual(a.Value, b.(*LiteralType).Value)
+	case *Null:
+		return true
+	case *ReferenceType:
+		b := b.(*ReferenceType)
+		return equalIdentifier(a.Name, b.Name) &&
+			equalList(a.Arguments, b.Arguments)
+	case *TupleType:
+		b := b.(*TupleType)
+		return a.Length == b.Length &&
+			Equal(a.Node, b.Node) &&
+			equalList(a.Elements, b.Elements)
+	case *FunctionType:
+		b := b.(*FunctionType)
+		return equalList(a.Parameters, b.Parameters) &&
+			Equal(a.Type, b.Type)
+	case *Parameter:
+		b := b.(*Parameter)
+		return a.Name == b.Name &&
+			a.Rest == b.Rest &&
+			a.QuestionToken == b.QuestionToken &&
+			Equal(a.Type, b.Type)
+	case *ArrayType:
+		return Equal(a.Node, b.(*ArrayType).Node)
+	case *ArrayLiteralType:
+		return equalList(a.Elements, b.(*ArrayLiteralType).Elements)
+	case *ObjectLiteral:
+		b := b.(*ObjectLiteral)
+		return equalList(a.Spreads, b.Spreads) &&
+			equalList(a.Properties, b.Properties)
+	case *PropertyAssignment:
+		b := b.(*PropertyAssignment)
+		return a.Name == b.Name &&
+			Equal(a.Initializer, b.Initializer) &&
+			a.SupportComments.equal(b.SupportComments)
+	case *PrefixUnaryExpression:
+		b := b.(*PrefixUnaryExpression)
+		return a.Operator == b.Operator &&
+			Equal(a.Operand, b.Operand)
+	case *BigIntLiteral:
+		return a.Value == b.(*BigIntLiteral).Value
+	case *TemplateLiteralType:
+		b := b.(*TemplateLiteralType)
+		return a.Head == b.Head &&
+			equalList(a.Spans, b.Spans)
+	case *TemplateLiteralTypeSpan:
+		b := b.(*TemplateLiteralTypeSpan)
+		return a.Literal == b.Literal &&
+			Equal(a.Type, b.Type)
+	case *UnionType:
+		return equalList(a.Types, b.(*UnionType).Types)
+	case *TypeIntersection:
+		return equalList(a.Types, b.(*TypeIntersection).Types)
+	case *TypeQuery:
+		return equalIdentifier(a.Name, b.(*TypeQuery).Name)
+	case *IndexedAccessType:
+		b := b.(*IndexedAccessType)
+		return Equal(a.Object, b.Object) &&
+			Equal(a.Index, b.Index)
+	case *AsConstExpression:
+		return Equal(a.Expression, b.(*AsConstExpression).Expression)
+	case *PropertyAccess:
+		b := b.(*PropertyAccess)
+		return equalIdentifier(a.Object, b.Object) &&
+			a.Name == b.Name
+	case *IdentifierExpression:
+		return equalIdentifier(a.Name, b.(*IdentifierExpression).Name)
+	case *MemberAccess:
+		b := b.(*MemberAccess)
+		return Equal(a.Expression, b.Expression) &&
+			a.Name == b.Name
+	case *CallExpression:
+		b := b.(*CallExpression)
+		return Equal(a.Expression, b.Expression) &&
+			equalList(a.Arguments, b.Arguments) &&
+			a.New == b.New
+	case *ArrowFunction:
+		b := b.(*ArrowFunction)
+		return equalList(a.Parameters, b.Parameters) &&
+			Equal(a.Body, b.Body)
+	case *ConditionalExpression:
+		b := b.(*ConditionalExpression)
+		return Equal(a.Condition, b.Condition) &&
+			Equal(a.WhenTrue, b.WhenTrue) &&
+			Equal(a.WhenFalse, b.WhenFalse)
+	case *BinaryExpression:
+		b := b.(*BinaryExpression)
+		return Equal(a.Left, b.Left) &&
+			a.Operator == b.Operator &&
+			Equal(a.Right, b.Right)
+	case *ExpressionWithTypeArguments:
+		b := b.(*ExpressionWithTypeArguments)
+		return Equal(a.Expression, b.Expression) &&
+			equalList(a.Arguments, b.Arguments)
+	case *VariableDeclarationList:
+		b := b.(*VariableDeclarationList)
+		return a.Flags == b.Flags &&
+			equalList(a.Declarations, b.Declarations)
+	case *VariableDeclaration:
+		b := b.(*VariableDeclaration)
+		return equalIdentifier(a.Name, b.Name) &&
+			a.ExclamationMark == b.ExclamationMark &&
+			Equal(a.Type, b.Type) &&
+			Equal(a.Initializer, b.Initializer)
+	case *OperatorNodeType:
+		b := b.(*OperatorNodeType)
+		return a.Keyword == b.Keyword &&
+			Equal(a.Type, b.Type)
+	case *EnumMember:
+		b := b.(*EnumMember)
+		return a.Name == b.Name &&
+			Equal(a.Value, b.Value) &&
+			a.SupportComments.equal(b.SupportComments)
+	case *TypeLiteralNode:
+		b := b.(*TypeLiteralNode)
+		return equalList(a.Members, b.Members) &&
+			equalList(a.Methods, b.Methods)
+	case *HeritageClause:
+		b := b.(*HeritageClause)
+		return a.Token == b.Token &&
+			equalList(a.Args, b.Args)
+	case *Interface:
+		b := b.(*Interface)
+		return equalIdentifier(a.Name, b.Name) &&
+			slices.Equal(a.Modifiers, b.Modifiers) &&
+			equalList(a.Fields, b.Fields) &&
+			equalList(a.Methods, b.Methods) &&
+			equalList(a.Parameters, b.Parameters) &&
+			equalList(a.Heritage, b.Heritage) &&
+			a.SupportComments.equal(b.SupportComments) &&
+			a.Source == b.Source
+	case *PropertySignature:
+		b := b.(*PropertySignature)
+		return a.Name == b.Name &&
+			slices.Equal(a.Modifiers, b.Modifiers) &&
+			a.QuestionToken == b.QuestionToken &&
+			Equal(a.Type, b.Type) &&
+			a.Constraints.Equal(b.Constraints) &&
+			a.SupportComments.equal(b.SupportComments)
+	case *MethodSignature:
+		b := b.(*MethodSignature)
+		return a.Name == b.Name &&
+			a.QuestionToken == b.QuestionToken &&
+			equalList(a.Parameters, b.Parameters) &&
+			Equal(a.Type, b.Type) &&
+			a.SupportComments.equal(b.SupportComments)
+	case *Alias:
+		b := b.(*Alias)
+		return equalIdentifier(a.Name, b.Name) &&
+			slices.Equal(a.Modifiers, b.Modifiers) &&
+			Equal(a.Type, b.Type) &&
+			equalList(a.Parameters, b.Parameters) &&
+			a.SupportComments.equal(b.SupportComments) &&
+			a.Source == b.Source
+	case *TypeParameter:
+		b := b.(*TypeParameter)
+		return equalIdentifier(a.Name, b.Name) &&
+			slices.Equal(a.Modifiers, b.Modifiers) &&
+			Equal(a.Type, b.Type) &&
+			Equal(a.DefaultType, b.DefaultType)
+	case *VariableStatement:
+		b := b.(*VariableStatement)
+		return slices.Equal(a.Modifiers, b.Modifiers) &&
+			Equal(a.Declarations, b.Declarations) &&
+			a.SupportComments.equal(b.SupportComments) &&
+			a.Source == b.Source
+	case *Enum:
+		b := b.(*Enum)
+		return equalIdentifier(a.Name, b.Name) &&
+			slices.Equal(a.Modifiers, b.Modifiers) &&
+			a.Const == b.Const &&
+			a.Object == b.Object &&
+			equalList(a.Members, b.Members) &&
+			a.SupportComments.equal(b.SupportComments) &&
+			a.Source == b.Source
+	case *Namespace:
+		b := b.(*Namespace)
+		return a.Name == b.Name &&
+			slices.Equal(a.Modifiers, b.Modifiers) &&
+			equalList(a.Statements, b.Statements) &&
+			a.Global == b.Global &&
+			a.SupportComments.equal(b.SupportComments)
+	case *FunctionDeclaration:
+		b := b.(*FunctionDeclaration)
+		return equalIdentifier(a.Name, b.Name) &&
+			slices.Equal(a.Modifiers, b.Modifiers) &&
+			equalList(a.Parameters, b.Parameters) &&
+			Equal(a.Type, b.Type) &&
+			Equal(a.Body, b.Body) &&
+			a.SupportComments.equal(b.SupportComments) &&
+			a.Source == b.Source
+	default:
+		panic(fmt.Sprintf("bindings.Equal: unexpected node type %T", a))
+	}
+}
+
+func equalList[N Node](a, b []N) bool {
+	return slices.EqualFunc(a, b, func(a, b N) bool {
+		return Equal(a, b)
+	})
+}
+
+func equalIdentifier(a, b Identifier) bool {
+	return a.Name == b.Name &&
+		a.Prefix == b.Prefix &&
+		a.Namespace == b.Namespace &&
+		a.PkgName() == b.PkgName()
+}
+
+func (s SupportComments) equal(o SupportComments) bool {
+	return slices.Equal(s.comments, o.comments)
+}
@@ -2,6 +2,7 @@ package bindings
 
 import (
 	"fmt"
+	"math/big"
 
 	"golang.org/x/xerrors"
 )
@@ -71,10 +72,14 @@ func (*ReferenceType) isNode()           {}
 func (*ReferenceType) isExpressionType() {}
 
 type TupleType struct {
-	// TODO: Technically tuples can be heterogeneous, but golang does not really
-	// support that. So just assume that all elements are the same type.
+	// Golang arrays are homogeneous tuples, where all elements are the same
+	// type. Node is the type of every element.
 	Node   ExpressionType
 	Length int
+	// Elements is the type of each element of a heterogeneous tuple. If set,
+	// Node and Length are ignored.
+	// [string, number]
+	Elements []ExpressionType
 }
 
 func (*TupleType) isNode()           {}
@@ -107,6 +112,121 @@ type ArrayLiteralType struct {
 func (*ArrayLiteralType) isNode()           {}
 func (*ArrayLiteralType) isExpressionType() {}
 
+// Tuple returns a heterogeneous tuple.
+func Tuple(elements ...ExpressionType) *TupleType {
+	return &TupleType{
+		Elements: elements,
+	}
+}
+
+// FunctionType is the type of a function.
+// (name: string, ...rest: number[]) => void
+type FunctionType struct {
+	Parameters []*Parameter
+	Type       ExpressionType
+}
+
+func (*FunctionType) isNode()           {}
+func (*FunctionType) isExpressionType() {}
+
+// Parameter is a parameter of a function.
+type Parameter struct {
+	Name string
+	// Rest is a variadic parameter, the type must be an array.
+	// ...name: string[]
+	Rest          bool
+	QuestionToken bool
+	Type          ExpressionType
+}
+
+func (*Parameter) isNode() {}
+
+// PrefixOperator is the operator of a PrefixUnaryExpression.
+type PrefixOperator string
+
+const (
+	PrefixMinus       PrefixOperator = "MinusToken"
+	PrefixPlus        PrefixOperator = "PlusToken"
+	PrefixTilde       PrefixOperator = "TildeToken"
+	PrefixExclamation PrefixOperator = "ExclamationToken"
+)
+
+// PrefixUnaryExpression applies an operator to a value.
+// -1
+type PrefixUnaryExpression struct {
+	Operator PrefixOperator
+	Operand  ExpressionType
+}
+
+func (*PrefixUnaryExpression) isNode()           {}
+func (*PrefixUnaryExpression) isExpressionType() {}
+
+func PrefixUnary(operator PrefixOperator, operand ExpressionType) *PrefixUnaryExpression {
+	return &PrefixUnaryExpression{
+		Operator: operator,
+		Operand:  operand,
+	}
+}
+
+// BigIntLiteral is an arbitrary precision integer value.
+// 9007199254740993n
+type BigIntLiteral struct {
+	// Value is the base 10 integer, without the 'n' suffix.
+	// Negative values are wrapped in a PrefixUnaryExpression when serialized.
+	Value string
+}
+
+func (*BigIntLiteral) isNode()           {}
+func (*BigIntLiteral) isExpressionType() {}
+
+// BigInt returns the literal of the integer.
+func BigInt(value *big.Int) *BigIntLiteral {
+	return &BigIntLiteral{Value: value.String()}
+}
+
+// TemplateLiteralType is a string type built from other types.
+// `${number}px`
+// - Head: ""
+// - Spans: [{Type: number, Literal: "px"}]
+type TemplateLiteralType struct {
+	Head  string
+	Spans []*TemplateLiteralTypeSpan
+}
+
+func (*TemplateLiteralType) isNode()           {}
+func (*TemplateLiteralType) isExpressionType() {}
+
+// TemplateLiteralTypeSpan is a type followed by the literal text up to the
+// next type, or the end of the template.
+type TemplateLiteralTypeSpan struct {
+	Type    ExpressionType
+	Literal string
+}
+
+func (*TemplateLiteralTypeSpan) isNode() {}
+
+// ObjectLiteral is an object value.
+// { name: "foo", count: 1 }
+type ObjectLiteral struct {
+	// Spreads are objects whose properties are copied before the properties.
+	// { ...value, name: "foo" }
+	Spreads    []ExpressionType
+	Properties []*PropertyAssignment
+}
+
+func (*ObjectLiteral) isNode()           {}
+func (*ObjectLiteral) isExpressionType() {}
+
+// PropertyAssignment is a property of an object literal.
+// name: "foo"
+type PropertyAssignment struct {
+	Name        string
+	Initializer ExpressionType
+	SupportComments
+}
+
+func (*PropertyAssignment) isNode() {}
+
 type UnionType struct {
 	Types []ExpressionType
 }
@@ -186,6 +306,7 @@ func (*EnumMember) isExpressionType() {}
 // TypeLiteralNode represents an object type literal like { name: string }
 type TypeLiteralNode struct {
 	Members []*PropertySignature
+	Methods []*MethodSignature
 }
 
 func (*TypeLiteralNode) isNode()           {}
@@ -197,3 +318,114 @@ type TypeIntersection struct {
 
 func (*TypeIntersection) isNode()           {}
 func (*TypeIntersection) isExpressionType() {}
+
+// TypeQuery is the type of a value.
+// typeof Foo
+type TypeQuery struct {
+	Name Identifier
+}
+
+func (*TypeQuery) isNode()           {}
+func (*TypeQuery) isExpressionType() {}
+
+// IndexedAccessType is the type of a property of another type.
+// Foo["bar"]
+type IndexedAccessType struct {
+	Object ExpressionType
+	Index  ExpressionType
+}
+
+func (*IndexedAccessType) isNode()           {}
+func (*IndexedAccessType) isExpressionType() {}
+
+// AsConstExpression makes a value literal and readonly.
+// { bar: "bar" } as const
+type AsConstExpression struct {
+	Expression ExpressionType
+}
+
+func (*AsConstExpression) isNode()           {}
+func (*AsConstExpression) isExpressionType() {}
+
+// PropertyAccess is a property of a value, like an enum member.
+// Foo.Bar
+type PropertyAccess struct {
+	Object Identifier
+	Name   string
+}
+
+func (*PropertyAccess) isNode()           {}
+func (*PropertyAccess) isExpressionType() {}
+
+// IdentifierExpression references a value by name.
+// decodeFoo
+type IdentifierExpression struct {
+	Name Identifier
+}
+
+func (*IdentifierExpression) isNode()           {}
+func (*IdentifierExpression) isExpressionType() {}
+
+// MemberAccess is a property of any value. Names that are not identifiers
+// are accessed by element.
+// value.items, value[0]
+type MemberAccess struct {
+	Expression ExpressionType
+	Name       string
+}
+
+func (*MemberAccess) isNode()           {}
+func (*MemberAccess) isExpressionType() {}
+
+// CallExpression calls a function, or a constructor if New is set.
+// decodeFoo(value), new Date(value)
+type CallExpression struct {
+	Expression ExpressionType
+	Arguments  []ExpressionType
+	New        bool
+}
+
+func (*CallExpression) isNode()           {}
+func (*CallExpression) isExpressionType() {}
+
+// ArrowFunction is a function that returns a single expression.
+// (v) => new Date(v)
+type ArrowFunction struct {
+	Parameters []*Parameter
+	Body       ExpressionType
+}
+
+func (*ArrowFunction) isNode()           {}
+func (*ArrowFunction) isExpressionType() {}
+
+// ConditionalExpression is the ternary operator.
+// value == null ? value : new Date(value)
+type ConditionalExpression struct {
+	Condition ExpressionType
+	WhenTrue  ExpressionType
+	WhenFalse ExpressionType
+}
+
+func (*ConditionalExpression) isNode()           {}
+func (*ConditionalExpression) isExpressionType() {}
+
+// BinaryOperator is the operator of a BinaryExpression.
+type BinaryOperator string
+
+const (
+	BinaryEquals          BinaryOperator = "EqualsEqualsToken"
+	BinaryStrictEquals    BinaryOperator = "EqualsEqualsEqualsToken"
+	BinaryNotEquals       BinaryOperator = "ExclamationEqualsToken"
+	BinaryStrictNotEquals BinaryOperator = "ExclamationEqualsEqualsToken"
+)
+
+// BinaryExpression compares two values.
+// value == null
+type BinaryExpression struct {
+	Left     ExpressionType
+	Operator BinaryOperator
+	Right    ExpressionType
+}
+
+func (*BinaryExpression) isNode()           {}
+func (*BinaryExpression) isExpressionType() {}
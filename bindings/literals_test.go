@@ -0,0 +1,249 @@
+package bindings_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/guts/bindings"
+)
+
+func TestLiteralNodes(t *testing.T) {
+	t.Parallel()
+
+	str := bindings.LiteralKeyword(bindings.KeywordString)
+	num := bindings.LiteralKeyword(bindings.KeywordNumber)
+	constant := func(name string, value bindings.ExpressionType) *bindings.VariableStatement {
+		return &bindings.VariableStatement{
+			Modifiers: []bindings.Modifier{bindings.ModifierExport},
+			Declarations: &bindings.VariableDeclarationList{
+				Declarations: []*bindings.VariableDeclaration{
+					{Name: bindings.Identifier{Name: name}, Initializer: value},
+				},
+				Flags: bindings.NodeFlagsConstant,
+			},
+		}
+	}
+	alias := func(name string, value bindings.ExpressionType) *bindings.Alias {
+		return &bindings.Alias{
+			Name:      bindings.Identifier{Name: name},
+			Modifiers: []bindings.Modifier{bindings.ModifierExport},
+			Type:      value,
+		}
+	}
+	wire := &bindings.IdentifierExpression{Name: bindings.Identifier{Name: "wire"}}
+
+	huge, ok := new(big.Int).SetString("-9007199254740993", 10)
+	require.True(t, ok)
+
+	cases := []struct {
+		name     string
+		node     bindings.Node
+		expected string
+	}{
+		{
+			name: "ObjectLiteral",
+			node: constant("Obj", &bindings.ObjectLiteral{
+				Properties: []*bindings.PropertyAssignment{
+					{Name: "a", Initializer: &bindings.LiteralType{Value: int64(1)}},
+					{Name: "with-dash", Initializer: &bindings.LiteralType{Value: "b"}},
+					{Name: "nested", Initializer: &bindings.ObjectLiteral{
+						Properties: []*bindings.PropertyAssignment{
+							{Name: "c", Initializer: &bindings.LiteralType{Value: true}},
+						},
+					}},
+				},
+			}),
+			expected: "export const Obj = {\n    a: 1,\n    \"with-dash\": \"b\",\n    nested: {\n        c: true\n    }\n};",
+		},
+		{
+			name:     "EmptyObjectLiteral",
+			node:     constant("Empty", &bindings.ObjectLiteral{}),
+			expected: "export const Empty = {};",
+		},
+		{
+			name:     "NegativeInteger",
+			node:     constant("Neg", &bindings.LiteralType{Value: int64(-5)}),
+			expected: "export const Neg = -5;",
+		},
+		{
+			name:     "NegativeFloat",
+			node:     constant("NegFloat", &bindings.LiteralType{Value: -1.5}),
+			expected: "export const NegFloat = -1.5;",
+		},
+		{
+			name:     "LargeInteger",
+			node:     constant("Large", &bindings.LiteralType{Value: uint64(18446744073709551615)}),
+			expected: "export const Large = 18446744073709551615;",
+		},
+		{
+			name:     "PrefixUnary",
+			node:     constant("Not", bindings.PrefixUnary(bindings.PrefixExclamation, &bindings.LiteralType{Value: false})),
+			expected: "export const Not = !false;",
+		},
+		{
+			name:     "BigInt",
+			node:     constant("Big", bindings.BigInt(big.NewInt(42))),
+			expected: "export const Big = 42n;",
+		},
+		{
+			name:     "NegativeBigInt",
+			node:     constant("NegBig", bindings.BigInt(huge)),
+			expected: "export const NegBig = -9007199254740993n;",
+		},
+		{
+			name: "TemplateLiteralType",
+			node: alias("Pixels", &bindings.TemplateLiteralType{
+				Head: "",
+				Spans: []*bindings.TemplateLiteralTypeSpan{
+					{Type: &num, Literal: "px"},
+				},
+			}),
+			expected: "export type Pixels = `${number}px`;",
+		},
+		{
+			name: "TemplateLiteralTypeMultiple",
+			node: alias("Route", &bindings.TemplateLiteralType{
+				Head: "/users/",
+				Spans: []*bindings.TemplateLiteralTypeSpan{
+					{Type: &str, Literal: "/posts/"},
+					{Type: &num, Literal: ""},
+				},
+			}),
+			expected: "export type Route = `/users/${string}/posts/${number}`;",
+		},
+		{
+			name: "FunctionType",
+			node: alias("Fn", &bindings.FunctionType{
+				Parameters: []*bindings.Parameter{
+					{Name: "name", Type: &str},
+					{Name: "limit", QuestionToken: true, Type: &num},
+					{Name: "rest", Rest: true, Type: bindings.Array(&str)},
+				},
+				Type: bindings.Tuple(&str, &num),
+			}),
+			expected: "export type Fn = (name: string, limit?: number, ...rest: string[]) => [\n    string,\n    number\n];",
+		},
+		{
+			name: "MethodSignature",
+			node: &bindings.Interface{
+				Name:      bindings.Identifier{Name: "Store"},
+				Modifiers: []bindings.Modifier{bindings.ModifierExport},
+				Methods: []*bindings.MethodSignature{
+					{
+						Name:       "Get",
+						Parameters: []*bindings.Parameter{{Name: "key", Type: &str}},
+						Type:       &num,
+					},
+				},
+			},
+			expected: "export interface Store {\n    Get(key: string): number;\n}",
+		},
+		{
+			name: "AsConst",
+			node: constant("Color", &bindings.AsConstExpression{
+				Expression: &bindings.ObjectLiteral{
+					Properties: []*bindings.PropertyAssignment{
+						{Name: "Red", Initializer: &bindings.LiteralType{Value: "red"}},
+					},
+				},
+			}),
+			expected: "export const Color = {\n    Red: \"red\"\n} as const;",
+		},
+		{
+			name: "IndexedAccessTypeQuery",
+			node: alias("Color", &bindings.IndexedAccessType{
+				Object: &bindings.TypeQuery{Name: bindings.Identifier{Name: "Color"}},
+				Index:  bindings.OperatorNode(bindings.KeywordKeyOf, &bindings.TypeQuery{Name: bindings.Identifier{Name: "Color"}}),
+			}),
+			expected: "export type Color = (typeof Color)[keyof typeof Color];",
+		},
+		{
+			name: "PropertyAccess",
+			node: constant("Colors", &bindings.ArrayLiteralType{
+				Elements: []bindings.ExpressionType{
+					&bindings.PropertyAccess{Object: bindings.Identifier{Name: "Color"}, Name: "Red"},
+				},
+			}),
+			expected: "export const Colors = [Color.Red];",
+		},
+		{
+			name: "ConstEnum",
+			node: &bindings.Enum{
+				Name:      bindings.Identifier{Name: "Color"},
+				Modifiers: []bindings.Modifier{bindings.ModifierExport},
+				Const:     true,
+				Members: []*bindings.EnumMember{
+					{Name: "Red", Value: &bindings.LiteralType{Value: "red"}},
+				},
+			},
+			expected: "export const enum Color {\n    Red = \"red\"\n}",
+		},
+		{
+			name: "FunctionBody",
+			node: &bindings.FunctionDeclaration{
+				Name:       bindings.Identifier{Name: "decodeEvent"},
+				Modifiers:  []bindings.Modifier{bindings.ModifierExport},
+				Parameters: []*bindings.Parameter{{Name: "wire", Type: bindings.Reference(bindings.Identifier{Name: "EventWire"})}},
+				Type:       bindings.Reference(bindings.Identifier{Name: "Event"}),
+				Body: &bindings.ObjectLiteral{
+					Spreads: []bindings.ExpressionType{wire},
+					Properties: []*bindings.PropertyAssignment{
+						{
+							Name: "at",
+							Initializer: &bindings.ConditionalExpression{
+								Condition: &bindings.BinaryExpression{
+									Left:     &bindings.MemberAccess{Expression: wire, Name: "at"},
+									Operator: bindings.BinaryEquals,
+									Right:    &bindings.Null{},
+								},
+								WhenTrue: &bindings.MemberAccess{Expression: wire, Name: "at"},
+								WhenFalse: &bindings.CallExpression{
+									Expression: &bindings.IdentifierExpression{Name: bindings.Identifier{Name: "Date"}},
+									Arguments:  []bindings.ExpressionType{&bindings.MemberAccess{Expression: wire, Name: "at"}},
+									New:        true,
+								},
+							},
+						},
+						{
+							Name: "tags",
+							Initializer: &bindings.CallExpression{
+								Expression: &bindings.MemberAccess{Expression: &bindings.MemberAccess{Expression: wire, Name: "tags"}, Name: "map"},
+								Arguments: []bindings.ExpressionType{
+									&bindings.ArrowFunction{
+										Parameters: []*bindings.Parameter{{Name: "v"}},
+										Body: &bindings.MemberAccess{
+											Expression: &bindings.IdentifierExpression{Name: bindings.Identifier{Name: "v"}},
+											Name:       "0",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: "export function decodeEvent(wire: EventWire): Event {\n    return {\n        ...wire,\n        at: wire.at == null ? wire.at : new Date(wire.at),\n        tags: wire.tags.map(v => v[0])\n    };\n}",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			b, err := bindings.New()
+			require.NoError(t, err)
+
+			obj, err := b.ToTypescriptNode(c.node)
+			require.NoError(t, err)
+
+			text, err := b.SerializeToTypescript(obj)
+			require.NoError(t, err)
+			require.Equal(t, c.expected, text)
+
+			// Every node must be supported by the clone and equality.
+			require.True(t, bindings.Equal(c.node, bindings.Clone(c.node)))
+		})
+	}
+}
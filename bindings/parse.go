@@ -0,0 +1,278 @@
+package bindings
+
+import (
+	"encoding/json"
+	"math/big"
+	"strconv"
+
+	"github.com/dop251/goja"
+	"golang.org/x/xerrors"
+)
+
+// parsedType is the json description of a parsed typescript type. See
+// 'parseType' in the typescript engine.
+type parsedType struct {
+	Kind       string          `json:"kind"`
+	Keyword    string          `json:"keyword"`
+	Value      string          `json:"value"`
+	Name       []string        `json:"name"`
+	Types      []*parsedType   `json:"types"`
+	Type       *parsedType     `json:"type"`
+	Index      *parsedType     `json:"index"`
+	Members    []*parsedMember `json:"members"`
+	Parameters []*parsedMember `json:"parameters"`
+	Head       string          `json:"head"`
+	Spans      []struct {
+		Type    *parsedType `json:"type"`
+		Literal string      `json:"literal"`
+	} `json:"spans"`
+}
+
+type parsedMember struct {
+	Name       string          `json:"name"`
+	Optional   bool            `json:"optional"`
+	Readonly   bool            `json:"readonly"`
+	Rest       bool            `json:"rest"`
+	Method     bool            `json:"method"`
+	Parameters []*parsedMember `json:"parameters"`
+	Type       *parsedType     `json:"type"`
+}
+
+// ParseTypescriptType parses the text of a single typescript type, like
+// 'Date' or 'Record<string, `${number}px`>'. References are not resolved, so
+// they can be to any declared type.
+func (b *Bindings) ParseTypescriptType(text string) (ExpressionType, error) {
+	parseF, err := b.f("parseType")
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := parseF(goja.Undefined(), b.vm.ToValue(text))
+	if err != nil {
+		return nil, xerrors.Errorf("parse %q: %w", text, err)
+	}
+
+	var parsed parsedType
+	err = json.Unmarshal([]byte(res.String()), &parsed)
+	if err != nil {
+		return nil, xerrors.Errorf("decode parsed type: %w", err)
+	}
+
+	exp, err := parsed.expression()
+	if err != nil {
+		return nil, xerrors.Errorf("parse %q: %w", text, err)
+	}
+	return exp, nil
+}
+
+func (p *parsedType) expression() (ExpressionType, error) {
+	switch p.Kind {
+	case "keyword":
+		keyword := LiteralKeyword(p.Keyword)
+		return &keyword, nil
+	case "null":
+		return &Null{}, nil
+	case "boolean":
+		return &LiteralType{Value: p.Value == "true"}, nil
+	case "string":
+		return &LiteralType{Value: p.Value}, nil
+	case "number":
+		if v, err := strconv.ParseInt(p.Value, 0, 64); err == nil {
+			return &LiteralType{Value: v}, nil
+		}
+		v, err := strconv.ParseFloat(p.Value, 64)
+		if err != nil {
+			return nil, xerrors.Errorf("number %q: %w", p.Value, err)
+		}
+		return &LiteralType{Value: v}, nil
+	case "bigint":
+		v, ok := new(big.Int).SetString(p.Value, 0)
+		if !ok {
+			return nil, xerrors.Errorf("bigint %q", p.Value)
+		}
+		return BigInt(v), nil
+	case "reference":
+		name, err := parsedIdentifier(p.Name)
+		if err != nil {
+			return nil, err
+		}
+		args, err := parsedExpressions(p.Types)
+		if err != nil {
+			return nil, xerrors.Errorf("reference %q: %w", name.Name, err)
+		}
+		return Reference(name, args...), nil
+	case "query":
+		name, err := parsedIdentifier(p.Name)
+		if err != nil {
+			return nil, err
+		}
+		return &TypeQuery{Name: name}, nil
+	case "array":
+		elem, err := p.Type.expression()
+		if err != nil {
+			return nil, err
+		}
+		return Array(elem), nil
+	case "tuple":
+		elems, err := parsedExpressions(p.Types)
+		if err != nil {
+			return nil, err
+		}
+		return Tuple(elems...), nil
+	case "union":
+		types, err := parsedExpressions(p.Types)
+		if err != nil {
+			return nil, err
+		}
+		return Union(types...), nil
+	case "intersection":
+		types, err := parsedExpressions(p.Types)
+		if err != nil {
+			return nil, err
+		}
+		return &TypeIntersection{Types: types}, nil
+	case "operator":
+		operand, err := p.Type.expression()
+		if err != nil {
+			return nil, err
+		}
+		return OperatorNode(LiteralKeyword(p.Keyword), operand), nil
+	case "indexed":
+		object, err := p.Type.expression()
+		if err != nil {
+			return nil, err
+		}
+		index, err := p.Index.expression()
+		if err != nil {
+			return nil, err
+		}
+		return &IndexedAccessType{Object: object, Index: index}, nil
+	case "template":
+		template := &TemplateLiteralType{Head: p.Head}
+		for _, span := range p.Spans {
+			ty, err := span.Type.expression()
+			if err != nil {
+				return nil, err
+			}
+			template.Spans = append(template.Spans, &TemplateLiteralTypeSpan{Type: ty, Literal: span.Literal})
+		}
+		return template, nil
+	case "object":
+		literal := &TypeLiteralNode{}
+		for _, member := range p.Members {
+			if member.Method {
+				method, err := member.method()
+				if err != nil {
+					return nil, err
+				}
+				literal.Methods = append(literal.Methods, method)
+				continue
+			}
+
+			property, err := member.property()
+			if err != nil {
+				return nil, err
+			}
+			literal.Members = append(literal.Members, property)
+		}
+		return literal, nil
+	case "function":
+		params, err := parsedParameters(p.Parameters)
+		if err != nil {
+			return nil, err
+		}
+		ret, err := p.Type.expression()
+		if err != nil {
+			return nil, err
+		}
+		return &FunctionType{Parameters: params, Type: ret}, nil
+	default:
+		return nil, xerrors.Errorf("unsupported parsed type %q", p.Kind)
+	}
+}
+
+func (m *parsedMember) property() (*PropertySignature, error) {
+	ty, err := m.typeOrAny()
+	if err != nil {
+		return nil, xerrors.Errorf("property %q: %w", m.Name, err)
+	}
+
+	property := &PropertySignature{
+		Name:          m.Name,
+		Modifiers:     []Modifier{},
+		QuestionToken: m.Optional,
+		Type:          ty,
+	}
+	if m.Readonly {
+		property.Modifiers = append(property.Modifiers, ModifierReadonly)
+	}
+	return property, nil
+}
+
+func (m *parsedMember) method() (*MethodSignature, error) {
+	params, err := parsedParameters(m.Parameters)
+	if err != nil {
+		return nil, xerrors.Errorf("method %q: %w", m.Name, err)
+	}
+	ret, err := m.typeOrAny()
+	if err != nil {
+		return nil, xerrors.Errorf("method %q: %w", m.Name, err)
+	}
+	return &MethodSignature{
+		Name:          m.Name,
+		QuestionToken: m.Optional,
+		Parameters:    params,
+		Type:          ret,
+	}, nil
+}
+
+// typeOrAny is the type of a member, which is implicitly 'any' if it is
+// omitted.
+func (m *parsedMember) typeOrAny() (ExpressionType, error) {
+	if m.Type == nil {
+		keyword := KeywordAny
+		return &keyword, nil
+	}
+	return m.Type.expression()
+}
+
+func parsedParameters(members []*parsedMember) ([]*Parameter, error) {
+	params := make([]*Parameter, 0, len(members))
+	for _, member := range members {
+		ty, err := member.typeOrAny()
+		if err != nil {
+			return nil, xerrors.Errorf("parameter %q: %w", member.Name, err)
+		}
+		params = append(params, &Parameter{
+			Name:          member.Name,
+			Rest:          member.Rest,
+			QuestionToken: member.Optional,
+			Type:          ty,
+		})
+	}
+	return params, nil
+}
+
+func parsedExpressions(parsed []*parsedType) ([]ExpressionType, error) {
+	exps := make([]ExpressionType, 0, len(parsed))
+	for _, p := range parsed {
+		exp, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+		exps = append(exps, exp)
+	}
+	return exps, nil
+}
+
+// parsedIdentifier is a plain, or namespace qualified, name.
+func parsedIdentifier(name []string) (Identifier, error) {
+	switch len(name) {
+	case 1:
+		return Identifier{Name: name[0]}, nil
+	case 2:
+		return Identifier{Namespace: name[0], Name: name[1]}, nil
+	default:
+		return Identifier{}, xerrors.Errorf("unsupported nested namespace %v", name)
+	}
+}
@@ -0,0 +1,69 @@
+package bindings_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/guts/bindings"
+)
+
+func TestParseTypescriptType(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		text     string
+		expected string
+	}{
+		{text: "Date", expected: "Date"},
+		{text: "string | null", expected: "string | null"},
+		{text: "Record<string, readonly number[]>", expected: "Record<string, readonly number[]>"},
+		{text: `Brand<string, "UserID">`, expected: `Brand<string, "UserID">`},
+		{text: "`${number}px`", expected: "`${number}px`"},
+		{text: "(string | number)[]", expected: "(string | number)[]"},
+		{text: "[string, -1, 10n, true]", expected: "[\n    string,\n    -1,\n    10n,\n    true\n]"},
+		{text: "api.User & { readonly id?: string }", expected: "api.User & {\n    readonly id?: string;\n}"},
+		{text: "(name: string, ...rest: unknown[]) => void", expected: "(name: string, ...rest: unknown[]) => void"},
+		{text: "keyof typeof Colors", expected: "keyof typeof Colors"},
+		{text: `Colors["red"]`, expected: `Colors["red"]`},
+		{text: "{ get(key: string): number }", expected: "{\n    get(key: string): number;\n}"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.text, func(t *testing.T) {
+			t.Parallel()
+
+			b, err := bindings.New()
+			require.NoError(t, err)
+
+			exp, err := b.ParseTypescriptType(c.text)
+			require.NoError(t, err)
+
+			obj, err := b.ToTypescriptNode(exp)
+			require.NoError(t, err)
+
+			text, err := b.SerializeToTypescript(obj)
+			require.NoError(t, err)
+			require.Equal(t, c.expected, text)
+		})
+	}
+
+	invalid := []string{
+		"string;",
+		"string; type Other = number",
+		"{ [key: string]: number }",
+		"T extends string ? T : never",
+		"a.b.C",
+	}
+	for _, text := range invalid {
+		t.Run(text, func(t *testing.T) {
+			t.Parallel()
+
+			b, err := bindings.New()
+			require.NoError(t, err)
+
+			_, err = b.ParseTypescriptType(text)
+			require.Error(t, err)
+		})
+	}
+}
@@ -10,6 +10,9 @@ import (
 
 type Bindings struct {
 	vm *goja.Runtime
+	// namespace is the namespace currently being serialized. References to
+	// identifiers in other namespaces are qualified.
+	namespace string
 }
 
 func New() (*Bindings, error) {
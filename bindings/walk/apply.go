@@ -0,0 +1,336 @@
+package walk
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/coder/guts/bindings"
+)
+
+// An ApplyFunc is invoked by Apply for each node n, even if n is nil,
+// before and/or after the node's children, using a Cursor describing
+// the current node and providing operations on it.
+//
+// The return value of ApplyFunc controls the syntax tree traversal.
+// See Apply for details.
+type ApplyFunc func(*Cursor) bool
+
+// Apply traverses a syntax tree recursively, starting with root,
+// and calling pre and post for each node as described below.
+// Apply returns the syntax tree, possibly modified.
+//
+// If pre is not nil, it is called for each node before the node's
+// children are traversed (pre-order). If pre returns false, no
+// children are traversed, and post is not called for that node.
+//
+// If post is not nil, and a prior call of pre didn't return false,
+// post is called for each node after its children are traversed
+// (post-order). If post returns false, traversal is terminated and
+// Apply returns immediately.
+//
+// Only fields that refer to typescript nodes are considered children.
+// Children are traversed in the same order as Walk.
+//
+// This is modeled after 'golang.org/x/tools/go/ast/astutil.Apply'.
+func Apply(root bindings.Node, pre, post ApplyFunc) (result bindings.Node) {
+	parent := &struct{ Node bindings.Node }{root}
+	defer func() {
+		if r := recover(); r != nil && r != abort {
+			panic(r)
+		}
+		result = parent.Node
+	}()
+	a := &application{pre: pre, post: post}
+	a.apply(parent, "Node", nil, root)
+	return
+}
+
+var abort = new(int) // singleton, to signal termination of Apply
+
+// A Cursor describes a node encountered during Apply.
+// Information about the node and its parent is available
+// from the Node, Parent, Name, and Index methods.
+//
+// If p is a variable of type and value of the current parent node
+// c.Parent(), and f is the field identifier with name c.Name(),
+// the following invariants hold:
+//
+//	p.f            == c.Node()  if c.Index() <  0
+//	p.f[c.Index()] == c.Node()  if c.Index() >= 0
+//
+// The methods Replace, Delete, InsertBefore, and InsertAfter
+// can be used to change the syntax tree.
+type Cursor struct {
+	parent any
+	name   string
+	iter   *iterator // valid if non-nil
+	node   bindings.Node
+}
+
+// Node returns the current Node.
+func (c *Cursor) Node() bindings.Node { return c.node }
+
+// Parent returns the parent of the current Node. The root node has a
+// parent that is not a typescript node.
+func (c *Cursor) Parent() bindings.Node {
+	n, _ := c.parent.(bindings.Node)
+	return n
+}
+
+// Name returns the name of the parent Node field that contains the current Node.
+// If the parent is a *bindings.UnionType and the current Node is one of its
+// types, Name returns "Types".
+func (c *Cursor) Name() string { return c.name }
+
+// Index reports the index >= 0 of the current Node in the slice of Nodes that
+// contains it, or a value < 0 if the current Node is not part of a slice.
+// The index of the current node changes if InsertBefore is called while
+// processing the current node.
+func (c *Cursor) Index() int {
+	if c.iter != nil {
+		return c.iter.index
+	}
+	return -1
+}
+
+// field returns the current node's parent field value.
+func (c *Cursor) field() reflect.Value {
+	return reflect.Indirect(reflect.ValueOf(c.parent)).FieldByName(c.name)
+}
+
+// Replace replaces the current Node with n.
+// The replacement node is not walked by Apply.
+func (c *Cursor) Replace(n bindings.Node) {
+	v := c.field()
+	if i := c.Index(); i >= 0 {
+		v = v.Index(i)
+	}
+	if n == nil {
+		v.Set(reflect.Zero(v.Type()))
+	} else {
+		v.Set(reflect.ValueOf(n))
+	}
+	c.node = n
+}
+
+// Delete deletes the current Node from its containing slice.
+// If the current Node is not part of a slice, Delete panics.
+func (c *Cursor) Delete() {
+	i := c.Index()
+	if i < 0 {
+		panic("Delete node not contained in slice")
+	}
+	v := c.field()
+	l := v.Len()
+	reflect.Copy(v.Slice(i, l), v.Slice(i+1, l))
+	v.Index(l - 1).Set(reflect.Zero(v.Type().Elem()))
+	v.SetLen(l - 1)
+	c.iter.step--
+}
+
+// InsertAfter inserts n after the current Node in its containing slice.
+// If the current Node is not part of a slice, InsertAfter panics.
+// Apply does not walk n.
+func (c *Cursor) InsertAfter(n bindings.Node) {
+	i := c.Index()
+	if i < 0 {
+		panic("InsertAfter node not contained in slice")
+	}
+	v := c.field()
+	v.Set(reflect.Append(v, reflect.Zero(v.Type().Elem())))
+	l := v.Len()
+	reflect.Copy(v.Slice(i+2, l), v.Slice(i+1, l))
+	v.Index(i + 1).Set(reflect.ValueOf(n))
+	c.iter.step++
+}
+
+// InsertBefore inserts n before the current Node in its containing slice.
+// If the current Node is not part of a slice, InsertBefore panics.
+// Apply will not walk n.
+func (c *Cursor) InsertBefore(n bindings.Node) {
+	i := c.Index()
+	if i < 0 {
+		panic("InsertBefore node not contained in slice")
+	}
+	v := c.field()
+	v.Set(reflect.Append(v, reflect.Zero(v.Type().Elem())))
+	l := v.Len()
+	reflect.Copy(v.Slice(i+1, l), v.Slice(i, l))
+	v.Index(i).Set(reflect.ValueOf(n))
+	c.iter.index++
+}
+
+// application carries all the shared data so we can pass it around cheaply.
+type application struct {
+	pre, post ApplyFunc
+	cursor    Cursor
+	iter      iterator
+}
+
+// An iterator controls iteration over a slice of nodes.
+type iterator struct {
+	index, step int
+}
+
+func (a *application) apply(parent any, name string, iter *iterator, n bindings.Node) {
+	// Typed nil pointers are treated as nil nodes.
+	if v := reflect.ValueOf(n); v.Kind() == reflect.Pointer && v.IsNil() {
+		n = nil
+	}
+
+	// avoid heap-allocating a new cursor for each apply call; reuse a.cursor instead
+	saved := a.cursor
+	a.cursor.parent = parent
+	a.cursor.name = name
+	a.cursor.iter = iter
+	a.cursor.node = n
+
+	if a.pre != nil && !a.pre(&a.cursor) {
+		a.cursor = saved
+		return
+	}
+
+	// walk children
+	// If there is a missing node, please add it.
+	switch n := a.cursor.node.(type) {
+	case nil:
+		// nothing to do
+	case *bindings.ArrayLiteralType:
+		a.applyList(n, "Elements")
+	case *bindings.ObjectLiteral:
+		a.applyList(n, "Spreads")
+		a.applyList(n, "Properties")
+	case *bindings.PropertyAssignment:
+		a.apply(n, "Initializer", nil, n.Initializer)
+	case *bindings.PrefixUnaryExpression:
+		a.apply(n, "Operand", nil, n.Operand)
+	case *bindings.BigIntLiteral:
+		// noop
+	case *bindings.TemplateLiteralType:
+		a.applyList(n, "Spans")
+	case *bindings.TemplateLiteralTypeSpan:
+		a.apply(n, "Type", nil, n.Type)
+	case *bindings.ArrayType:
+		a.apply(n, "Node", nil, n.Node)
+	case *bindings.TupleType:
+		a.apply(n, "Node", nil, n.Node)
+		a.applyList(n, "Elements")
+	case *bindings.FunctionType:
+		a.applyList(n, "Parameters")
+		a.apply(n, "Type", nil, n.Type)
+	case *bindings.Parameter:
+		a.apply(n, "Type", nil, n.Type)
+	case *bindings.Interface:
+		a.applyList(n, "Parameters")
+		a.applyList(n, "Heritage")
+		a.applyList(n, "Fields")
+		a.applyList(n, "Methods")
+	case *bindings.PropertySignature:
+		a.apply(n, "Type", nil, n.Type)
+	case *bindings.MethodSignature:
+		a.applyList(n, "Parameters")
+		a.apply(n, "Type", nil, n.Type)
+	case *bindings.Alias:
+		a.apply(n, "Type", nil, n.Type)
+	case *bindings.TypeParameter:
+		a.apply(n, "Type", nil, n.Type)
+	case *bindings.UnionType:
+		a.applyList(n, "Types")
+	case *bindings.Enum:
+		a.applyList(n, "Members")
+	case *bindings.VariableStatement:
+		a.apply(n, "Declarations", nil, n.Declarations)
+	case *bindings.VariableDeclarationList:
+		a.applyList(n, "Declarations")
+	case *bindings.VariableDeclaration:
+		a.apply(n, "Type", nil, n.Type)
+		a.apply(n, "Initializer", nil, n.Initializer)
+	case *bindings.ReferenceType:
+		a.applyList(n, "Arguments")
+	case *bindings.LiteralKeyword:
+		// noop
+	case *bindings.LiteralType:
+		// noop
+	case *bindings.Null:
+		// noop
+	case *bindings.HeritageClause:
+		a.applyList(n, "Args")
+	case *bindings.ExpressionWithTypeArguments:
+		a.apply(n, "Expression", nil, n.Expression)
+		a.applyList(n, "Arguments")
+	case *bindings.OperatorNodeType:
+		a.apply(n, "Type", nil, n.Type)
+	case *bindings.EnumMember:
+		a.apply(n, "Value", nil, n.Value)
+	case *bindings.TypeLiteralNode:
+		a.applyList(n, "Members")
+		a.applyList(n, "Methods")
+	case *bindings.TypeIntersection:
+		a.applyList(n, "Types")
+	case *bindings.TypeQuery:
+		// noop
+	case *bindings.IndexedAccessType:
+		a.apply(n, "Object", nil, n.Object)
+		a.apply(n, "Index", nil, n.Index)
+	case *bindings.AsConstExpression:
+		a.apply(n, "Expression", nil, n.Expression)
+	case *bindings.PropertyAccess:
+		// noop
+	case *bindings.IdentifierExpression:
+		// noop
+	case *bindings.MemberAccess:
+		a.apply(n, "Expression", nil, n.Expression)
+	case *bindings.CallExpression:
+		a.apply(n, "Expression", nil, n.Expression)
+		a.applyList(n, "Arguments")
+	case *bindings.ArrowFunction:
+		a.applyList(n, "Parameters")
+		a.apply(n, "Body", nil, n.Body)
+	case *bindings.ConditionalExpression:
+		a.apply(n, "Condition", nil, n.Condition)
+		a.apply(n, "WhenTrue", nil, n.WhenTrue)
+		a.apply(n, "WhenFalse", nil, n.WhenFalse)
+	case *bindings.BinaryExpression:
+		a.apply(n, "Left", nil, n.Left)
+		a.apply(n, "Right", nil, n.Right)
+	case *bindings.Namespace:
+		a.applyList(n, "Statements")
+	case *bindings.FunctionDeclaration:
+		a.applyList(n, "Parameters")
+		a.apply(n, "Type", nil, n.Type)
+		a.apply(n, "Body", nil, n.Body)
+	default:
+		panic(fmt.Sprintf("walk.Apply: unexpected node type %T", n))
+	}
+
+	if a.post != nil && !a.post(&a.cursor) {
+		panic(abort)
+	}
+
+	a.cursor = saved
+}
+
+// applyList applies to each element of the parent's slice field.
+func (a *application) applyList(parent bindings.Node, name string) {
+	// avoid heap-allocating a new iterator for each applyList call; reuse a.iter instead
+	saved := a.iter
+	a.iter.index = 0
+	for {
+		// must reload parent.name each time, since cursor modifications might change it
+		v := reflect.Indirect(reflect.ValueOf(parent)).FieldByName(name)
+		if a.iter.index >= v.Len() {
+			break
+		}
+
+		// element x may be nil in a bad AST - be cautious
+		var x bindings.Node
+		if e := v.Index(a.iter.index); e.IsValid() && e.CanInterface() {
+			x, _ = e.Interface().(bindings.Node)
+		}
+
+		a.iter.step = 1
+		a.apply(parent, name, &a.iter, x)
+		a.iter.index += a.iter.step
+	}
+	a.iter = saved
+}
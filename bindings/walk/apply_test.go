@@ -0,0 +1,142 @@
+package walk_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/guts/bindings"
+	"github.com/coder/guts/bindings/walk"
+)
+
+func TestApply(t *testing.T) {
+	t.Parallel()
+
+	keyword := func(k bindings.LiteralKeyword) *bindings.LiteralKeyword { return &k }
+	str := keyword(bindings.KeywordString)
+	num := keyword(bindings.KeywordNumber)
+	boolean := keyword(bindings.KeywordBoolean)
+
+	t.Run("Cursor", func(t *testing.T) {
+		t.Parallel()
+
+		union := bindings.Union(str, num)
+		array := bindings.Array(union)
+		var found bool
+		walk.Apply(array, func(c *walk.Cursor) bool {
+			if c.Node() == num {
+				found = true
+				require.Equal(t, union, c.Parent())
+				require.Equal(t, "Types", c.Name())
+				require.Equal(t, 1, c.Index())
+			}
+			if c.Node() == union {
+				require.Equal(t, array, c.Parent())
+				require.Equal(t, "Node", c.Name())
+				require.Equal(t, -1, c.Index())
+			}
+			return true
+		}, nil)
+		require.True(t, found)
+	})
+
+	t.Run("Replace", func(t *testing.T) {
+		t.Parallel()
+
+		array := bindings.Array(bindings.Union(str, &bindings.Null{}))
+		walk.Apply(array, func(c *walk.Cursor) bool {
+			if _, ok := c.Node().(*bindings.UnionType); ok {
+				c.Replace(str)
+			}
+			return true
+		}, nil)
+		require.Equal(t, str, array.Node)
+	})
+
+	t.Run("ReplaceRoot", func(t *testing.T) {
+		t.Parallel()
+
+		result := walk.Apply(str, func(c *walk.Cursor) bool {
+			c.Replace(num)
+			return true
+		}, nil)
+		require.Equal(t, num, result)
+	})
+
+	t.Run("DeleteAndInsert", func(t *testing.T) {
+		t.Parallel()
+
+		union := bindings.Union(str, num, boolean)
+		var visited []bindings.Node
+		walk.Apply(union, func(c *walk.Cursor) bool {
+			visited = append(visited, c.Node())
+			switch c.Node() {
+			case str:
+				c.Delete()
+			case num:
+				c.InsertBefore(bindings.Reference(bindings.Identifier{Name: "Before"}))
+				c.InsertAfter(bindings.Reference(bindings.Identifier{Name: "After"}))
+			}
+			return true
+		}, nil)
+
+		// Inserted nodes are not walked.
+		require.Equal(t, []bindings.Node{union, str, num, boolean}, visited)
+		require.Len(t, union.Types, 4)
+		require.Equal(t, "Before", union.Types[0].(*bindings.ReferenceType).Name.Name)
+		require.Equal(t, num, union.Types[1])
+		require.Equal(t, "After", union.Types[2].(*bindings.ReferenceType).Name.Name)
+		require.Equal(t, boolean, union.Types[3])
+	})
+
+	t.Run("Heritage", func(t *testing.T) {
+		t.Parallel()
+
+		// interface Foo extends Base<string> {}
+		base := &bindings.ExpressionWithTypeArguments{
+			Expression: bindings.Reference(bindings.Identifier{Name: "Base"}),
+			Arguments:  []bindings.ExpressionType{str},
+		}
+		intf := &bindings.Interface{
+			Name:     bindings.Identifier{Name: "Foo"},
+			Heritage: []*bindings.HeritageClause{bindings.HeritageClauseExtends(base)},
+		}
+		walk.Apply(intf, func(c *walk.Cursor) bool {
+			if c.Node() == str {
+				require.Equal(t, base, c.Parent())
+				require.Equal(t, "Arguments", c.Name())
+				c.Replace(num)
+			}
+			return true
+		}, nil)
+		require.Equal(t, []bindings.ExpressionType{num}, base.Arguments)
+
+		var visited []bindings.Node
+		walk.Walk(visitFunc(func(node bindings.Node) {
+			visited = append(visited, node)
+		}), intf)
+		require.Contains(t, visited, base.Expression)
+		require.Contains(t, visited, num)
+	})
+
+	t.Run("Abort", func(t *testing.T) {
+		t.Parallel()
+
+		union := bindings.Union(str, num, boolean)
+		var visited int
+		walk.Apply(union, nil, func(c *walk.Cursor) bool {
+			visited++
+			return c.Node() != num
+		})
+		require.Equal(t, 2, visited)
+	})
+}
+
+type visitFunc func(node bindings.Node)
+
+func (f visitFunc) Visit(node bindings.Node) walk.Visitor {
+	if node != nil {
+		f(node)
+	}
+	return f
+}
@@ -27,16 +27,39 @@ func Walk(v Visitor, node bindings.Node) {
 	switch n := node.(type) {
 	case *bindings.ArrayLiteralType:
 		walkList(v, n.Elements)
+	case *bindings.ObjectLiteral:
+		walkList(v, n.Spreads)
+		walkList(v, n.Properties)
+	case *bindings.PropertyAssignment:
+		Walk(v, n.Initializer)
+	case *bindings.PrefixUnaryExpression:
+		Walk(v, n.Operand)
+	case *bindings.BigIntLiteral:
+		// noop
+	case *bindings.TemplateLiteralType:
+		walkList(v, n.Spans)
+	case *bindings.TemplateLiteralTypeSpan:
+		Walk(v, n.Type)
 	case *bindings.ArrayType:
 		Walk(v, n.Node)
 	case *bindings.TupleType:
 		Walk(v, n.Node)
+		walkList(v, n.Elements)
+	case *bindings.FunctionType:
+		walkList(v, n.Parameters)
+		Walk(v, n.Type)
+	case *bindings.Parameter:
+		Walk(v, n.Type)
 	case *bindings.Interface:
 		walkList(v, n.Parameters)
 		walkList(v, n.Heritage)
 		walkList(v, n.Fields)
+		walkList(v, n.Methods)
 	case *bindings.PropertySignature:
 		Walk(v, n.Type)
+	case *bindings.MethodSignature:
+		walkList(v, n.Parameters)
+		Walk(v, n.Type)
 	case *bindings.Alias:
 		Walk(v, n.Type)
 	case *bindings.TypeParameter:
@@ -62,14 +85,50 @@ func Walk(v Visitor, node bindings.Node) {
 		// noop
 	case *bindings.HeritageClause:
 		walkList(v, n.Args)
+	case *bindings.ExpressionWithTypeArguments:
+		Walk(v, n.Expression)
+		walkList(v, n.Arguments)
 	case *bindings.OperatorNodeType:
 		Walk(v, n.Type)
 	case *bindings.EnumMember:
 		Walk(v, n.Value)
 	case *bindings.TypeLiteralNode:
 		walkList(v, n.Members)
+		walkList(v, n.Methods)
 	case *bindings.TypeIntersection:
 		walkList(v, n.Types)
+	case *bindings.TypeQuery:
+		// noop
+	case *bindings.IndexedAccessType:
+		Walk(v, n.Object)
+		Walk(v, n.Index)
+	case *bindings.AsConstExpression:
+		Walk(v, n.Expression)
+	case *bindings.PropertyAccess:
+		// noop
+	case *bindings.IdentifierExpression:
+		// noop
+	case *bindings.MemberAccess:
+		Walk(v, n.Expression)
+	case *bindings.CallExpression:
+		Walk(v, n.Expression)
+		walkList(v, n.Arguments)
+	case *bindings.ArrowFunction:
+		walkList(v, n.Parameters)
+		Walk(v, n.Body)
+	case *bindings.ConditionalExpression:
+		Walk(v, n.Condition)
+		Walk(v, n.WhenTrue)
+		Walk(v, n.WhenFalse)
+	case *bindings.BinaryExpression:
+		Walk(v, n.Left)
+		Walk(v, n.Right)
+	case *bindings.Namespace:
+		walkList(v, n.Statements)
+	case *bindings.FunctionDeclaration:
+		walkList(v, n.Parameters)
+		Walk(v, n.Type)
+		Walk(v, n.Body)
 	default:
 		panic(fmt.Sprintf("convert.Walk: unexpected node type %T", n))
 	}
@@ -0,0 +1,144 @@
+package guts
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/coder/guts/bindings"
+)
+
+// brandProperty is the phantom property that makes branded types nominal.
+const brandProperty = "__brand"
+
+// BrandedTypes makes named scalar types nominal, so they cannot be used in
+// place of each other. Enums, type aliases and non-scalar types are not
+// branded.
+//
+//	type UserID string --> type UserID = string & { readonly __brand: "UserID" }
+//
+// Values must be cast to the branded type, eg: 'id as UserID'.
+//
+// Types defined from an overridden type use the override, and are branded.
+// Methods are not inherited by a defined type, so the type must have its own
+// 'MarshalJSON' or 'MarshalText' method. Otherwise the json is the structure
+// of the type, which is generated instead.
+//
+//	type WorkspaceID uuid.UUID
+//	func (id WorkspaceID) MarshalText() ([]byte, error)
+//	--> type WorkspaceID = string & { readonly __brand: "WorkspaceID" }
+func (p *GoParser) BrandedTypes() *GoParser {
+	p.brandedTypes = true
+	return p
+}
+
+// brand returns the branded type of a named scalar type, if branding is
+// enabled.
+func (ts *Typescript) brand(obj types.Object, ty bindings.ExpressionType) bindings.ExpressionType {
+	if !ts.parsed.brandedTypes {
+		return ty
+	}
+	if _, ok := obj.Type().(*types.Named); !ok {
+		// Type aliases are the same type.
+		return ty
+	}
+	if !isScalar(ty) {
+		return ty
+	}
+
+	name := ts.parsed.Identifier(obj).Qualified()
+	brand := &bindings.TypeLiteralNode{
+		Members: []*bindings.PropertySignature{
+			{
+				Name:      brandProperty,
+				Modifiers: []bindings.Modifier{bindings.ModifierReadonly},
+				Type:      &bindings.LiteralType{Value: name},
+			},
+		},
+	}
+
+	if intersection, ok := ty.(*bindings.TypeIntersection); ok {
+		intersection.Types = append(intersection.Types, brand)
+		return intersection
+	}
+	return &bindings.TypeIntersection{
+		Types: []bindings.ExpressionType{ty, brand},
+	}
+}
+
+// isScalar returns true if the type is a single json primitive value.
+// Format types are strings, so they are scalar too.
+func isScalar(ty bindings.ExpressionType) bool {
+	switch ty := ty.(type) {
+	case *bindings.LiteralKeyword:
+		switch *ty {
+		case bindings.KeywordString, bindings.KeywordNumber, bindings.KeywordBoolean, bindings.KeywordBigInt:
+			return true
+		}
+	case *bindings.TemplateLiteralType:
+		return true
+	case *bindings.TypeIntersection:
+		// An already branded type, like a format.
+		return len(ty.Types) > 0 && isScalar(ty.Types[0])
+	case *bindings.UnionType:
+		// A format with multiple shapes, like an ipv4 or ipv6 address.
+		for _, t := range ty.Types {
+			if !isScalar(t) {
+				return false
+			}
+		}
+		return len(ty.Types) > 0
+	}
+	return false
+}
+
+// definedFrom returns the named type a type is defined from, if the type
+// marshals itself like the original type does.
+// type WorkspaceID uuid.UUID --> uuid.UUID
+// The underlying type of 'WorkspaceID' is '[16]byte', so the original type is
+// found in the declaration.
+func (p *GoParser) definedFrom(obj *types.TypeName) (types.Type, bool) {
+	if obj.IsAlias() || obj.Pkg() == nil {
+		return nil, false
+	}
+	named, ok := obj.Type().(*types.Named)
+	if !ok || !marshalsItself(named) {
+		// The marshal methods of the original type are not inherited.
+		return nil, false
+	}
+	pkg, ok := p.Pkgs[obj.Pkg().Path()]
+	if !ok || pkg.TypesInfo == nil {
+		return nil, false
+	}
+
+	for _, file := range pkg.Syntax {
+		if obj.Pos() < file.Pos() || obj.Pos() >= file.End() {
+			continue
+		}
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				typeSpec, ok := spec.(*ast.TypeSpec)
+				if !ok || typeSpec.Name.Pos() != obj.Pos() {
+					continue
+				}
+
+				switch defined := pkg.TypesInfo.TypeOf(typeSpec.Type).(type) {
+				case *types.Named, *types.Alias:
+					return defined, true
+				}
+				return nil, false
+			}
+		}
+	}
+	return nil, false
+}
+
+// marshalsItself returns true if the type has a json or text marshal method.
+func marshalsItself(named *types.Named) bool {
+	bytesErr := []types.Type{types.NewSlice(types.Typ[types.Byte]), types.Universe.Lookup("error").Type()}
+	return hasMethod(named, "MarshalJSON", 0, bytesErr...) || hasMethod(named, "MarshalText", 0, bytesErr...)
+}
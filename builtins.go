@@ -8,6 +8,8 @@ var (
 	builtInComparable = bindings.Identifier{Name: "Comparable"}
 	// builtInRecord is a reference to the 'Record' type in Typescript.
 	builtInRecord = bindings.Identifier{Name: "Record"}
+	// builtInPromise is a reference to the 'Promise' type in Typescript.
+	builtInPromise = bindings.Identifier{Name: "Promise"}
 )
 
 // RecordReference creates a reference to the 'Record' type in Typescript.
@@ -19,7 +21,7 @@ func RecordReference(key, value bindings.ExpressionType) *bindings.ReferenceType
 func (ts *Typescript) includeComparable() {
 	// The zzz just pushes it to the end of the sorting.
 	// Kinda strange, but it works.
-	_ = ts.setNode(builtInComparable.Ref(), typescriptNode{
+	_ = ts.setNode(builtInComparable, builtInComparable.GoName(), typescriptNode{
 		Node: &bindings.Alias{
 			Name:      builtInComparable,
 			Modifiers: []bindings.Modifier{},
@@ -0,0 +1,696 @@
+package guts
+
+import (
+	"go/types"
+	"maps"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/coder/guts/bindings"
+	"github.com/coder/guts/bindings/walk"
+)
+
+// RichType is the javascript type of a golang type, and how it is converted
+// from and to its json value.
+type RichType struct {
+	// Type is the typescript type of the decoded value.
+	Type TypeOverride
+	// Decode converts the json value into the rich value.
+	Decode func(value bindings.ExpressionType) bindings.ExpressionType
+	// Encode converts the rich value into the json value.
+	Encode func(value bindings.ExpressionType) bindings.ExpressionType
+}
+
+// CodecOptions configures which golang types have a rich javascript type.
+type CodecOptions struct {
+	// Types are the golang types with a rich type, keyed by the fully
+	// qualified type name. Eg: "time.Time"
+	Types map[string]RichType
+	// BigInt decodes 64 bit integers as 'bigint'. The integers must use the
+	// 'string' Int64Policy, as 'JSON.parse' loses the precision of larger
+	// numbers before they can be decoded.
+	BigInt bool
+	// Maps decodes golang maps with string keys as a 'Map', rather than a
+	// 'Record'.
+	Maps bool
+}
+
+// Codecs generates a rich variant of every type that contains a golang type
+// with a rich javascript type, like a 'Date' for a 'time.Time'. The json
+// shape is renamed with a 'Wire' suffix, and functions convert between the
+// two.
+//
+//	export interface UserWire { created_at: string; }
+//	export interface User { created_at: Date; }
+//	export function decodeUser(wire: UserWire): User
+//	export function encodeUser(rich: User): UserWire
+//
+// The conversions recurse through references, arrays, records and nullable
+// values. Fixed length arrays, and maps with named keys, are not converted.
+// Generic types are not converted either, so a field with a generic type
+// that contains a rich type is an error.
+func (p *GoParser) Codecs(opts CodecOptions) *GoParser {
+	p.codecs = &opts
+	return p
+}
+
+// codecGenerator builds the rich variants and codec functions of the
+// generated types.
+type codecGenerator struct {
+	ts   *Typescript
+	opts CodecOptions
+	// keys are the node keys of the types that can have a rich variant.
+	keys map[*types.TypeName]string
+	// rich are the types with a rich variant, and the identifier of the rich
+	// variant.
+	rich map[*types.TypeName]bindings.Identifier
+
+	// generics are the generic types that contain a rich type, and
+	// integers are the 64 bit integers that cannot be decoded as a bigint.
+	// Both are unsupported.
+	generics map[string]struct{}
+	integers map[string]struct{}
+	// visiting are the generic types being checked, as they can be recursive.
+	visiting map[string]bool
+}
+
+// generateCodecs adds the rich variants, and codec functions, of all types
+// that contain a rich type.
+func (ts *Typescript) generateCodecs() error {
+	c := &codecGenerator{
+		ts:   ts,
+		opts: *ts.parsed.codecs,
+		keys: make(map[*types.TypeName]string),
+		rich: make(map[*types.TypeName]bindings.Identifier),
+
+		generics: make(map[string]struct{}),
+		integers: make(map[string]struct{}),
+		visiting: make(map[string]bool),
+	}
+
+	for key, obj := range ts.objects {
+		obj, ok := obj.(*types.TypeName)
+		if !ok {
+			continue
+		}
+		node, ok := ts.Node(key)
+		if !ok {
+			continue
+		}
+		switch node := node.(type) {
+		case *bindings.Interface:
+			if len(node.Parameters) == 0 {
+				c.keys[obj] = key
+			}
+		case *bindings.Alias:
+			if len(node.Parameters) == 0 {
+				c.keys[obj] = key
+			}
+		}
+	}
+
+	// A type is rich if it contains a rich type, which can be a reference to
+	// another rich type. Repeat until no more types are found, as references
+	// can be circular.
+	for changed := true; changed; {
+		changed = false
+		for obj, key := range c.keys {
+			if _, ok := c.rich[obj]; ok {
+				continue
+			}
+			node, _ := ts.Node(key)
+			if c.declarationConverts(obj, node) {
+				c.rich[obj] = declarationName(node)
+				changed = true
+			}
+		}
+	}
+	if len(c.integers) > 0 {
+		return xerrors.Errorf("64 bit integers must use the %q int64 policy to be decoded as a bigint: %s",
+			Int64String, strings.Join(slices.Sorted(maps.Keys(c.integers)), ", "))
+	}
+	if len(c.generics) > 0 {
+		return xerrors.Errorf("generic types with rich types are not converted: %s",
+			strings.Join(slices.Sorted(maps.Keys(c.generics)), ", "))
+	}
+	if len(c.rich) == 0 {
+		return nil
+	}
+
+	objs := make([]*types.TypeName, 0, len(c.rich))
+	for obj := range c.rich {
+		objs = append(objs, obj)
+	}
+	sort.Slice(objs, func(i, j int) bool {
+		return c.keys[objs[i]] < c.keys[objs[j]]
+	})
+
+	// The existing types are the json shape, so all references to rich
+	// types are references to the wire variant.
+	wires := make(map[string]bindings.Identifier, len(c.rich))
+	for _, obj := range objs {
+		wires[c.keys[obj]] = wireName(c.rich[obj])
+	}
+	for _, node := range ts.typescriptNodes {
+		walk.Walk(&wireReferences{wires: wires}, node.Node)
+	}
+
+	for _, obj := range objs {
+		key := c.keys[obj]
+		wire := ts.typescriptNodes[key]
+		rich, err := c.richDeclaration(obj, wire.Node)
+		if err != nil {
+			return xerrors.Errorf("codec %q: %w", key, err)
+		}
+		decode, encode, err := c.functions(obj, wire.Node)
+		if err != nil {
+			return xerrors.Errorf("codec %q: %w", key, err)
+		}
+		setDeclarationName(wire.Node, wires[key])
+
+		nodes := map[string]bindings.Node{
+			wires[key].Qualified():  wire.Node,
+			decode.Name.Qualified(): decode,
+			encode.Name.Qualified(): encode,
+		}
+		for name, node := range nodes {
+			if _, ok := ts.typescriptNodes[name]; ok {
+				return xerrors.Errorf("codec %q: %q already exists", key, name)
+			}
+			ts.typescriptNodes[name] = &typescriptNode{Node: node}
+		}
+		ts.objects[wires[key].Qualified()] = obj
+		ts.typescriptNodes[key] = &typescriptNode{Node: rich}
+	}
+	return nil
+}
+
+// declarationConverts returns true if any field of a struct, or the type of
+// an alias, is converted. All fields are checked, so every unsupported type
+// is found.
+func (c *codecGenerator) declarationConverts(obj *types.TypeName, node bindings.Node) bool {
+	switch node := node.(type) {
+	case *bindings.Interface:
+		converts := false
+		for _, field := range node.Fields {
+			v, ok := c.field(obj, field)
+			if ok && c.converts(v.Type()) {
+				converts = true
+			}
+		}
+		for _, embedded := range c.embedded(obj) {
+			if c.converts(embedded) {
+				converts = true
+			}
+		}
+		return converts
+	case *bindings.Alias:
+		return c.converts(obj.Type().Underlying())
+	}
+	return false
+}
+
+// embedded returns the types of the embedded structs, which are inherited.
+func (c *codecGenerator) embedded(obj *types.TypeName) []types.Type {
+	st, ok := obj.Type().Underlying().(*types.Struct)
+	if !ok {
+		return nil
+	}
+	var embedded []types.Type
+	for i := 0; i < st.NumFields(); i++ {
+		field := st.Field(i)
+		if !field.Embedded() || reflect.StructTag(st.Tag(i)).Get("json") != "" {
+			continue
+		}
+		ty := field.Type()
+		for {
+			ptr, ok := ty.(*types.Pointer)
+			if !ok {
+				break
+			}
+			ty = ptr.Elem()
+		}
+		embedded = append(embedded, ty)
+	}
+	return embedded
+}
+
+// converts returns true if the json value of a type is different from the
+// rich value.
+func (c *codecGenerator) converts(ty types.Type) bool {
+	if _, ok := c.richType(ty); ok {
+		return true
+	}
+
+	switch t := ty.(type) {
+	case *types.Alias:
+		return c.converts(types.Unalias(t))
+	case *types.Named:
+		if _, ok := c.ts.parsed.customOverride(t); ok {
+			return false
+		}
+		if t.TypeArgs().Len() > 0 {
+			if c.genericConverts(t) {
+				c.generics[t.String()] = struct{}{}
+			}
+			return false
+		}
+		_, ok := c.rich[t.Obj()]
+		return ok
+	case *types.Pointer:
+		return c.converts(t.Elem())
+	case *types.Slice:
+		if t.Elem().String() == "byte" {
+			// []byte is a base64 string
+			return false
+		}
+		return c.converts(t.Elem())
+	case *types.Map:
+		if !isBasicKey(t) {
+			return false
+		}
+		return c.converts(t.Elem()) || (c.opts.Maps && isStringKey(t))
+	}
+	return false
+}
+
+// genericConverts returns true if an instance of a generic type contains a
+// rich type. The rich type can come from the type arguments, or from the
+// generic type itself.
+func (c *codecGenerator) genericConverts(ty *types.Named) bool {
+	key := ty.String()
+	if c.visiting[key] {
+		return false
+	}
+	c.visiting[key] = true
+	defer delete(c.visiting, key)
+
+	st, ok := ty.Underlying().(*types.Struct)
+	if !ok {
+		return c.converts(ty.Underlying())
+	}
+	converts := false
+	for i := 0; i < st.NumFields(); i++ {
+		field := st.Field(i)
+		if !field.Exported() || reflect.StructTag(st.Tag(i)).Get("json") == "-" {
+			continue
+		}
+		// Keep checking, so every unsupported type is reported.
+		if c.converts(field.Type()) {
+			converts = true
+		}
+	}
+	return converts
+}
+
+// field returns the golang field of a generated field. Fields with an
+// overridden type are not converted.
+func (c *codecGenerator) field(obj *types.TypeName, field *bindings.PropertySignature) (*types.Var, bool) {
+	f, ok := c.ts.fields[field]
+	if !ok {
+		return nil, false
+	}
+	if override, ok := c.ts.parsed.fieldOverride(obj, f.Var); ok && override.Type != nil {
+		return nil, false
+	}
+	return f.Var, true
+}
+
+// richType returns the rich type of a configured golang type, or a 64 bit
+// integer.
+func (c *codecGenerator) richType(ty types.Type) (RichType, bool) {
+	if rich, ok := c.opts.Types[ty.String()]; ok {
+		return rich, true
+	}
+
+	basic, ok := ty.(*types.Basic)
+	if !ok || !c.opts.BigInt {
+		return RichType{}, false
+	}
+	policy, ok := c.ts.parsed.int64PolicyFor(basic)
+	if !ok || policy == Int64BigInt {
+		return RichType{}, false
+	}
+	if policy != Int64String {
+		// The number is already imprecise once it is parsed.
+		c.integers[basic.String()] = struct{}{}
+		return RichType{}, false
+	}
+	return RichType{
+		Type: func() bindings.ExpressionType {
+			return ptr(bindings.KeywordBigInt)
+		},
+		// BigInt(value)
+		Decode: func(value bindings.ExpressionType) bindings.ExpressionType {
+			return call(identifier("BigInt"), value)
+		},
+		// value.toString()
+		Encode: func(value bindings.ExpressionType) bindings.ExpressionType {
+			return call(&bindings.MemberAccess{Expression: value, Name: "toString"})
+		},
+	}, true
+}
+
+// richDeclaration copies the wire declaration, with the rich types.
+func (c *codecGenerator) richDeclaration(obj *types.TypeName, wire bindings.Node) (bindings.Node, error) {
+	switch wire := wire.(type) {
+	case *bindings.Interface:
+		rich := bindings.Clone(wire)
+		for i, field := range wire.Fields {
+			// The rich fields are from the same golang fields.
+			if f, ok := c.ts.fields[field]; ok {
+				c.ts.fields[rich.Fields[i]] = f
+			}
+			v, ok := c.field(obj, field)
+			if !ok || !c.converts(v.Type()) {
+				continue
+			}
+			rich.Fields[i].Type = c.richTypeOf(v.Type(), field.Type)
+		}
+		// Rich types inherit the rich variant.
+		names := &wireReferences{wires: c.richNames()}
+		for _, heritage := range rich.Heritage {
+			walk.Walk(names, heritage)
+		}
+		return rich, nil
+	case *bindings.Alias:
+		rich := bindings.Clone(wire)
+		rich.Type = c.richTypeOf(obj.Type().Underlying(), wire.Type)
+		return rich, nil
+	default:
+		return nil, xerrors.Errorf("unsupported node %T", wire)
+	}
+}
+
+// richTypeOf converts the wire type of a golang type to the rich type.
+func (c *codecGenerator) richTypeOf(ty types.Type, wire bindings.ExpressionType) bindings.ExpressionType {
+	if rich, ok := c.richType(ty); ok {
+		return rich.Type()
+	}
+
+	if union, ok := wire.(*bindings.UnionType); ok {
+		members := make([]bindings.ExpressionType, 0, len(union.Types))
+		for _, member := range union.Types {
+			if _, isNull := member.(*bindings.Null); isNull {
+				members = append(members, &bindings.Null{})
+				continue
+			}
+			members = append(members, c.richTypeOf(ty, member))
+		}
+		return bindings.Union(members...)
+	}
+
+	switch t := ty.(type) {
+	case *types.Alias:
+		return c.richTypeOf(types.Unalias(t), wire)
+	case *types.Named:
+		if ident, ok := c.rich[t.Obj()]; ok {
+			return bindings.Reference(ident)
+		}
+	case *types.Pointer:
+		return c.richTypeOf(t.Elem(), wire)
+	case *types.Slice:
+		if array, ok := wire.(*bindings.ArrayType); ok {
+			return bindings.Array(c.richTypeOf(t.Elem(), array.Node))
+		}
+	case *types.Map:
+		record, ok := wire.(*bindings.ReferenceType)
+		if !ok || len(record.Arguments) != 2 {
+			break
+		}
+		name := record.Name
+		if c.opts.Maps && isStringKey(t) {
+			name = bindings.Identifier{Name: "Map"}
+		}
+		return bindings.Reference(name, bindings.Clone(record.Arguments[0]), c.richTypeOf(t.Elem(), record.Arguments[1]))
+	}
+	return bindings.Clone(wire)
+}
+
+// functions returns the decode and encode functions of a type.
+func (c *codecGenerator) functions(obj *types.TypeName, node bindings.Node) (*bindings.FunctionDeclaration, *bindings.FunctionDeclaration, error) {
+	rich := c.rich[obj]
+	wire := wireName(rich)
+
+	var decode, encode bindings.ExpressionType
+	switch node := node.(type) {
+	case *bindings.Interface:
+		decode = c.object(obj, node, identifier("wire"), true)
+		encode = c.object(obj, node, identifier("rich"), false)
+	case *bindings.Alias:
+		decode = c.convert(obj.Type().Underlying(), identifier("wire"), true)
+		encode = c.convert(obj.Type().Underlying(), identifier("rich"), false)
+	default:
+		return nil, nil, xerrors.Errorf("unsupported node %T", node)
+	}
+
+	source := declarationSource(node)
+	return &bindings.FunctionDeclaration{
+		Name:       codecName("decode", rich),
+		Modifiers:  []bindings.Modifier{},
+		Parameters: []*bindings.Parameter{{Name: "wire", Type: bindings.Reference(wire)}},
+		Type:       bindings.Reference(rich),
+		Body:       decode,
+		Source:     source,
+	}, &bindings.FunctionDeclaration{
+		Name:       codecName("encode", rich),
+		Modifiers:  []bindings.Modifier{},
+		Parameters: []*bindings.Parameter{{Name: "rich", Type: bindings.Reference(rich)}},
+		Type:       bindings.Reference(wire),
+		Body:       encode,
+		Source:     source,
+	}, nil
+}
+
+// object copies a struct value, and converts the fields that are different.
+//
+//	{ ...wire, ...decodeBase(wire), created_at: new Date(wire.created_at) }
+func (c *codecGenerator) object(obj *types.TypeName, node *bindings.Interface, value bindings.ExpressionType, decode bool) bindings.ExpressionType {
+	object := &bindings.ObjectLiteral{
+		Spreads:    []bindings.ExpressionType{value},
+		Properties: []*bindings.PropertyAssignment{},
+	}
+	for _, embedded := range c.embedded(obj) {
+		if c.converts(embedded) {
+			object.Spreads = append(object.Spreads, c.convert(embedded, value, decode))
+		}
+	}
+
+	for _, field := range node.Fields {
+		v, ok := c.field(obj, field)
+		if !ok || !c.converts(v.Type()) {
+			continue
+		}
+		access := &bindings.MemberAccess{Expression: value, Name: field.Name}
+		converted := c.convert(v.Type(), access, decode)
+		if field.QuestionToken && !isNullable(v.Type()) {
+			// Omitted fields stay omitted.
+			converted = nullable(access, converted)
+		}
+		object.Properties = append(object.Properties, &bindings.PropertyAssignment{
+			Name:        field.Name,
+			Initializer: converted,
+		})
+	}
+	return object
+}
+
+// convert converts a value of a golang type.
+func (c *codecGenerator) convert(ty types.Type, value bindings.ExpressionType, decode bool) bindings.ExpressionType {
+	if rich, ok := c.richType(ty); ok {
+		if decode {
+			return rich.Decode(value)
+		}
+		return rich.Encode(value)
+	}
+
+	switch t := ty.(type) {
+	case *types.Alias:
+		return c.convert(types.Unalias(t), value, decode)
+	case *types.Named:
+		ident := c.rich[t.Obj()]
+		if decode {
+			return call(&bindings.IdentifierExpression{Name: codecName("decode", ident)}, value)
+		}
+		return call(&bindings.IdentifierExpression{Name: codecName("encode", ident)}, value)
+	case *types.Pointer:
+		return nullable(value, c.convert(t.Elem(), value, decode))
+	case *types.Slice:
+		// value.map((v) => convert(v))
+		elem := identifier("v")
+		return nullable(value, call(
+			&bindings.MemberAccess{Expression: value, Name: "map"},
+			arrow("v", c.convert(t.Elem(), elem, decode)),
+		))
+	case *types.Map:
+		return nullable(value, c.convertMap(t, value, decode))
+	}
+	return value
+}
+
+// convertMap converts the values of a map. The entries are copied to a new
+// object, or 'Map'.
+func (c *codecGenerator) convertMap(ty *types.Map, value bindings.ExpressionType, decode bool) bindings.ExpressionType {
+	asMap := c.opts.Maps && isStringKey(ty)
+
+	// (e) => [e[0], convert(e[1])] as const
+	var entry bindings.ExpressionType
+	if c.converts(ty.Elem()) {
+		e := identifier("e")
+		entry = arrow("e", &bindings.AsConstExpression{
+			Expression: &bindings.ArrayLiteralType{
+				Elements: []bindings.ExpressionType{
+					&bindings.MemberAccess{Expression: e, Name: "0"},
+					c.convert(ty.Elem(), &bindings.MemberAccess{Expression: e, Name: "1"}, decode),
+				},
+			},
+		})
+	}
+
+	switch {
+	case decode:
+		// Object.entries(value).map(entry)
+		entries := call(&bindings.MemberAccess{Expression: identifier("Object"), Name: "entries"}, value)
+		if entry != nil {
+			entries = call(&bindings.MemberAccess{Expression: entries, Name: "map"}, entry)
+		}
+		if asMap {
+			return &bindings.CallExpression{Expression: identifier("Map"), Arguments: []bindings.ExpressionType{entries}, New: true}
+		}
+		return call(&bindings.MemberAccess{Expression: identifier("Object"), Name: "fromEntries"}, entries)
+	case asMap:
+		// Object.fromEntries(Array.from(value, entry))
+		entries := value
+		if entry != nil {
+			entries = call(&bindings.MemberAccess{Expression: identifier("Array"), Name: "from"}, value, entry)
+		}
+		return call(&bindings.MemberAccess{Expression: identifier("Object"), Name: "fromEntries"}, entries)
+	default:
+		// Object.fromEntries(Object.entries(value).map(entry))
+		entries := call(&bindings.MemberAccess{Expression: identifier("Object"), Name: "entries"}, value)
+		entries = call(&bindings.MemberAccess{Expression: entries, Name: "map"}, entry)
+		return call(&bindings.MemberAccess{Expression: identifier("Object"), Name: "fromEntries"}, entries)
+	}
+}
+
+// richNames maps the wire variants back to the rich variants.
+func (c *codecGenerator) richNames() map[string]bindings.Identifier {
+	names := make(map[string]bindings.Identifier, len(c.rich))
+	for _, ident := range c.rich {
+		names[wireName(ident).Qualified()] = ident
+	}
+	return names
+}
+
+// wireReferences renames the references to rich types.
+type wireReferences struct {
+	wires map[string]bindings.Identifier
+}
+
+func (v *wireReferences) Visit(node bindings.Node) walk.Visitor {
+	if ref, ok := node.(*bindings.ReferenceType); ok {
+		if name, ok := v.wires[ref.Name.Qualified()]; ok {
+			ref.Name = name
+		}
+	}
+	return v
+}
+
+func wireName(ident bindings.Identifier) bindings.Identifier {
+	ident.Name += "Wire"
+	return ident
+}
+
+// codecName is the name of a codec function. The function is declared
+// alongside the type, so it shares the same package and namespace.
+func codecName(prefix string, ident bindings.Identifier) bindings.Identifier {
+	ident.Name = prefix + ident.Name
+	return ident
+}
+
+func declarationName(node bindings.Node) bindings.Identifier {
+	switch node := node.(type) {
+	case *bindings.Interface:
+		return node.Name
+	case *bindings.Alias:
+		return node.Name
+	}
+	return bindings.Identifier{}
+}
+
+func setDeclarationName(node bindings.Node, ident bindings.Identifier) {
+	switch node := node.(type) {
+	case *bindings.Interface:
+		node.Name = ident
+	case *bindings.Alias:
+		node.Name = ident
+	}
+}
+
+func declarationSource(node bindings.Node) bindings.Source {
+	switch node := node.(type) {
+	case *bindings.Interface:
+		return node.Source
+	case *bindings.Alias:
+		return node.Source
+	}
+	return bindings.Source{}
+}
+
+// nullable only converts a value that is not null or undefined.
+//
+//	value == null ? value : converted
+func nullable(value, converted bindings.ExpressionType) bindings.ExpressionType {
+	return &bindings.ConditionalExpression{
+		Condition: &bindings.BinaryExpression{
+			Left:     bindings.Clone(value),
+			Operator: bindings.BinaryEquals,
+			Right:    &bindings.Null{},
+		},
+		WhenTrue:  bindings.Clone(value),
+		WhenFalse: converted,
+	}
+}
+
+// isNullable returns true if the json value of a type can be null, which
+// 'convert' already checks.
+func isNullable(ty types.Type) bool {
+	switch types.Unalias(ty).(type) {
+	case *types.Pointer, *types.Slice, *types.Map:
+		return true
+	}
+	return false
+}
+
+func call(fn bindings.ExpressionType, args ...bindings.ExpressionType) bindings.ExpressionType {
+	return &bindings.CallExpression{Expression: fn, Arguments: args}
+}
+
+func arrow(param string, body bindings.ExpressionType) bindings.ExpressionType {
+	return &bindings.ArrowFunction{
+		Parameters: []*bindings.Parameter{{Name: param}},
+		Body:       body,
+	}
+}
+
+// identifier references a value that is not generated, like a parameter or
+// a javascript built in.
+func identifier(name string) bindings.ExpressionType {
+	return &bindings.IdentifierExpression{Name: bindings.Identifier{Name: name}}
+}
+
+// isBasicKey returns true if the map keys are a plain string or number. The
+// keys of a converted map lose any named type.
+func isBasicKey(ty *types.Map) bool {
+	_, ok := ty.Key().(*types.Basic)
+	return ok
+}
+
+func isStringKey(ty *types.Map) bool {
+	basic, ok := ty.Key().(*types.Basic)
+	return ok && basic.Info()&types.IsString > 0
+}
@@ -0,0 +1,241 @@
+package guts
+
+import (
+	"fmt"
+	"go/types"
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/coder/guts/bindings"
+)
+
+// Collision is a typescript identifier that more than one golang object
+// would be generated as. All generated code lands in the same typescript
+// namespace, so the identifiers must be unique.
+type Collision struct {
+	// Name is the typescript identifier.
+	Name string
+	// GoNames are the fully qualified golang names of the colliding objects.
+	GoNames []string
+}
+
+func (c Collision) String() string {
+	return fmt.Sprintf("%q is generated by %s", c.Name, strings.Join(c.GoNames, ", "))
+}
+
+// CollisionError is returned by 'ToTypescript' when golang objects from
+// different packages are generated with the same typescript identifier.
+type CollisionError struct {
+	Collisions []Collision
+}
+
+func (e *CollisionError) Error() string {
+	var str strings.Builder
+	str.WriteString("typescript identifier collisions, resolve with 'PrefixCollisions', 'RenameTypes', 'PackageNamespaces', or a package prefix:")
+	for _, c := range e.Collisions {
+		str.WriteString("\n\t" + c.String())
+	}
+	return str.String()
+}
+
+// PrefixCollisions resolves identifier collisions by prefixing the colliding
+// types with their package name. If the package names are also the same,
+// more of the package path is used.
+// Eg: "github.com/a/foo.User" -> "FooUser"
+func (p *GoParser) PrefixCollisions() *GoParser {
+	p.prefixCollisions = true
+	return p
+}
+
+// RenameTypes changes the typescript identifier of golang types. The key is
+// the fully qualified golang name, and the value is the typescript name.
+// Eg: "github.com/your/repo/pkg.User": "PkgUser"
+func (p *GoParser) RenameTypes(renames map[GolangType]string) error {
+	for k, v := range renames {
+		if !isIdentifier(v) {
+			return fmt.Errorf("rename %q: %q is not a valid identifier", k, v)
+		}
+		p.renames[k] = v
+	}
+	return nil
+}
+
+// Collisions analyzes all included packages for golang objects that would be
+// generated with the same typescript identifier. Types in referenced packages
+// are included, even if they are never referenced. Variables and wasm globals
+// are included when they are generated.
+func (p *GoParser) Collisions() []Collision {
+	if p.namespaces {
+		p.namespaceNames = p.buildNamespaceNames()
+	}
+
+	owners := make(map[string]map[string]struct{})
+	own := func(ident bindings.Identifier, owner string) {
+		ref := ident.Qualified()
+		if _, ok := owners[ref]; !ok {
+			owners[ref] = make(map[string]struct{})
+		}
+		owners[ref][owner] = struct{}{}
+	}
+
+	for _, pkg := range p.Pkgs {
+		scope := pkg.Types.Scope()
+		for _, name := range scope.Names() {
+			obj := scope.Lookup(name)
+			if !obj.Exported() || !p.generatesDeclaration(pkg, obj) {
+				continue
+			}
+			if _, ok := p.Skips[obj.Type().String()]; ok {
+				continue
+			}
+			own(p.Identifier(obj), qualifiedName(obj))
+		}
+
+		if p.wasmGlobals {
+			for _, ident := range wasmGlobalIdentifiers(pkg) {
+				own(ident, ident.GoName())
+			}
+		}
+	}
+
+	return collisions(owners)
+}
+
+// generatesDeclaration returns true if the package level object would be
+// generated as a top level typescript declaration.
+func (p *GoParser) generatesDeclaration(pkg *packages.Package, obj types.Object) bool {
+	switch obj := obj.(type) {
+	case *types.TypeName:
+		return true
+	case *types.Const:
+		// Constants of named types are enum members.
+		_, basic := obj.Type().(*types.Basic)
+		return basic
+	case *types.Var:
+		if !p.generateVariables {
+			return false
+		}
+		_, ok := generatedVarValue(pkg, obj)
+		return ok
+	default:
+		return false
+	}
+}
+
+func collisions(owners map[string]map[string]struct{}) []Collision {
+	var found []Collision
+	for ref, names := range owners {
+		if len(names) < 2 {
+			continue
+		}
+		c := Collision{Name: ref}
+		for name := range names {
+			c.GoNames = append(c.GoNames, name)
+		}
+		sort.Strings(c.GoNames)
+		found = append(found, c)
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].Name < found[j].Name })
+	return found
+}
+
+// buildCollisionPrefixes returns the prefix to use for each colliding object.
+// The prefix is built from the package path, using as many path elements as
+// needed to make the identifiers unique.
+func (p *GoParser) buildCollisionPrefixes() map[string]string {
+	prefixes := make(map[string]string)
+	for _, c := range p.Collisions() {
+		for depth := 1; ; depth++ {
+			used := make(map[string]struct{})
+			unique, exhausted := true, true
+			for _, goName := range c.GoNames {
+				pkgPath := goName[:strings.LastIndex(goName, ".")]
+				prefix, more := packagePrefix(pkgPath, depth)
+				exhausted = exhausted && !more
+				if _, ok := used[prefix]; ok {
+					unique = false
+				}
+				used[prefix] = struct{}{}
+				prefixes[goName] = prefix
+			}
+			if unique || exhausted {
+				break
+			}
+		}
+	}
+	return prefixes
+}
+
+// packagePrefix converts the last 'depth' elements of the package path into
+// a typescript friendly prefix. The second return is true if there are more
+// path elements that could be used.
+func packagePrefix(pkgPath string, depth int) (string, bool) {
+	parts := strings.FieldsFunc(pkgPath, func(r rune) bool { return r == '/' })
+	more := len(parts) > depth
+	if more {
+		parts = parts[len(parts)-depth:]
+	}
+
+	var prefix strings.Builder
+	for _, part := range parts {
+		upper := true
+		for _, r := range part {
+			if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+				upper = true
+				continue
+			}
+			if upper {
+				r = unicode.ToUpper(r)
+				upper = false
+			}
+			prefix.WriteRune(r)
+		}
+	}
+	return prefix.String(), more
+}
+
+// claim records the golang object that owns a typescript identifier. The
+// owner is the fully qualified golang name, which is unaffected by renames.
+// It returns false if a different golang object already owns the identifier.
+func (ts *Typescript) claim(ident bindings.Identifier, owner string) bool {
+	if ts.owners == nil {
+		ts.owners = make(map[string]string)
+		ts.collisions = make(map[string]map[string]struct{})
+	}
+
+	key := ident.Qualified()
+	existing, ok := ts.owners[key]
+	if !ok {
+		ts.owners[key] = owner
+		return true
+	}
+	if existing == owner {
+		return true
+	}
+
+	if _, ok := ts.collisions[key]; !ok {
+		ts.collisions[key] = map[string]struct{}{existing: {}}
+	}
+	ts.collisions[key][owner] = struct{}{}
+	return false
+}
+
+func qualifiedName(obj types.Object) string {
+	if obj.Pkg() == nil {
+		return obj.Name()
+	}
+	return obj.Pkg().Path() + "." + obj.Name()
+}
+
+func isIdentifier(name string) bool {
+	for i, r := range name {
+		if r == '_' || r == '$' || unicode.IsLetter(r) || (i > 0 && unicode.IsDigit(r)) {
+			continue
+		}
+		return false
+	}
+	return name != ""
+}
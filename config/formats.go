@@ -0,0 +1,96 @@
+package config
+
+import (
+	"github.com/coder/guts"
+	"github.com/coder/guts/bindings"
+)
+
+// FormatStyle is how well known string formats are represented.
+type FormatStyle string
+
+const (
+	// FormatTemplate uses template literal types that match the shape of the
+	// format. Any string of the right shape is accepted.
+	// uuid.UUID --> `${string}-${string}-${string}-${string}-${string}`
+	FormatTemplate FormatStyle = "template"
+	// FormatBranded uses branded strings. Values must be cast to the format.
+	// uuid.UUID --> string & { readonly __format: "uuid" }
+	FormatBranded FormatStyle = "branded"
+)
+
+// FormatMappings maps golang types with a well known string format to more
+// specific types than 'string'. Include these after 'StandardMappings' to
+// replace the plain strings.
+func FormatMappings(style FormatStyle) map[string]guts.TypeOverride {
+	format := func(name string, template func() bindings.ExpressionType) guts.TypeOverride {
+		if style == FormatBranded {
+			return formatBrand(name)
+		}
+		return template
+	}
+
+	uuid := format("uuid", func() bindings.ExpressionType {
+		return templateLiteral(span(str(), "-"), span(str(), "-"), span(str(), "-"), span(str(), "-"), span(str(), ""))
+	})
+	ip := format("ip", func() bindings.ExpressionType {
+		return bindings.Union(
+			templateLiteral(span(num(), "."), span(num(), "."), span(num(), "."), span(num(), "")),
+			templateLiteral(span(str(), ":"), span(str(), "")),
+		)
+	})
+	return map[string]guts.TypeOverride{
+		// RFC 3339
+		"time.Time": format("date-time", func() bindings.ExpressionType {
+			return templateLiteral(span(num(), "-"), span(num(), "-"), span(num(), "T"), span(str(), ""))
+		}),
+
+		"github.com/google/uuid.UUID":     uuid,
+		"github.com/google/uuid.NullUUID": OverrideNullable(uuid),
+
+		"net/netip.Addr": ip,
+		"net/netip.Prefix": format("ip-prefix", func() bindings.ExpressionType {
+			return templateLiteral(span(str(), "/"), span(num(), ""))
+		}),
+		"net/netip.AddrPort": format("ip-port", func() bindings.ExpressionType {
+			return templateLiteral(span(str(), ":"), span(num(), ""))
+		}),
+	}
+}
+
+// formatBrand is a string branded with the name of the format.
+func formatBrand(name string) guts.TypeOverride {
+	return func() bindings.ExpressionType {
+		return &bindings.TypeIntersection{
+			Types: []bindings.ExpressionType{
+				str(),
+				&bindings.TypeLiteralNode{
+					Members: []*bindings.PropertySignature{
+						{
+							Name:      "__format",
+							Modifiers: []bindings.Modifier{bindings.ModifierReadonly},
+							Type:      &bindings.LiteralType{Value: name},
+						},
+					},
+				},
+			},
+		}
+	}
+}
+
+// templateLiteral builds a template literal type that starts with a type.
+func templateLiteral(spans ...*bindings.TemplateLiteralTypeSpan) *bindings.TemplateLiteralType {
+	return &bindings.TemplateLiteralType{Spans: spans}
+}
+
+// span is a type followed by literal text in a template literal.
+func span(ty bindings.ExpressionType, literal string) *bindings.TemplateLiteralTypeSpan {
+	return &bindings.TemplateLiteralTypeSpan{Type: ty, Literal: literal}
+}
+
+func str() bindings.ExpressionType {
+	return ptr(bindings.KeywordString)
+}
+
+func num() bindings.ExpressionType {
+	return ptr(bindings.KeywordNumber)
+}
@@ -5,6 +5,14 @@ import (
 	"github.com/coder/guts/bindings"
 )
 
+// OverrideType overrides with a plain typescript expression. Every use of the
+// override gets its own copy of the expression.
+func OverrideType(t bindings.ExpressionType) guts.TypeOverride {
+	return func() bindings.ExpressionType {
+		return bindings.Clone(t)
+	}
+}
+
 func OverrideLiteral(keyword bindings.LiteralKeyword) guts.TypeOverride {
 	return func() bindings.ExpressionType {
 		return ptr(keyword)
@@ -40,6 +48,31 @@ func StandardMappings() map[string]guts.TypeOverride {
 	}
 }
 
+// RichTypes are the javascript types of standard golang types, to use with
+// 'GoParser.Codecs'.
+func RichTypes() map[string]guts.RichType {
+	return map[string]guts.RichType{
+		// The json value is an RFC 3339 string, which 'Date' parses.
+		"time.Time": {
+			Type: func() bindings.ExpressionType {
+				return bindings.Reference(bindings.Identifier{Name: "Date"})
+			},
+			Decode: func(value bindings.ExpressionType) bindings.ExpressionType {
+				return &bindings.CallExpression{
+					Expression: &bindings.IdentifierExpression{Name: bindings.Identifier{Name: "Date"}},
+					Arguments:  []bindings.ExpressionType{value},
+					New:        true,
+				}
+			},
+			Encode: func(value bindings.ExpressionType) bindings.ExpressionType {
+				return &bindings.CallExpression{
+					Expression: &bindings.MemberAccess{Expression: value, Name: "toISOString"},
+				}
+			},
+		},
+	}
+}
+
 func ptr[T any](v T) *T {
 	return &v
 }
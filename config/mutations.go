@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"go/types"
 	"log/slog"
 	"reflect"
 	"slices"
@@ -59,6 +60,11 @@ func ExportTypes(ts *guts.Typescript) {
 			node.Modifiers = append(node.Modifiers, bindings.ModifierExport)
 		case *bindings.Enum:
 			node.Modifiers = append(node.Modifiers, bindings.ModifierExport)
+		case *bindings.FunctionDeclaration:
+			if node.Name.Namespace == "" || node.Body != nil {
+				node.Modifiers = append(node.Modifiers, bindings.ModifierExport)
+			}
+			// Functions declared in the global scope are not exported.
 		default:
 			panic(fmt.Sprintf("unexpected node type %T for exporting", node))
 		}
@@ -66,42 +72,127 @@ func ExportTypes(ts *guts.Typescript) {
 }
 
 // ReadOnly sets all interface fields to 'readonly', resulting in
-// all types being immutable.
-// TODO: follow the AST all the way and find nested arrays
+// all types being immutable. The entire type is made immutable, including
+// nested arrays, tuples, records and type literals, and the parameters and
+// returns of methods and function types. Declared functions are not changed.
+// string[][] --> readonly (readonly string[])[]
+// Record<string, string> --> Readonly<Record<string, string>>
 func ReadOnly(ts *guts.Typescript) {
 	ts.ForEach(func(key string, node bindings.Node) {
 		switch node := node.(type) {
 		case *bindings.Alias:
-			if _, isArray := node.Type.(*bindings.ArrayType); isArray {
-				node.Type = bindings.OperatorNode(bindings.KeywordReadonly, node.Type)
-			}
+			walk.Walk(&readOnlyVisitor{}, node)
 		case *bindings.Interface:
-			for _, prop := range node.Fields {
-				prop.Modifiers = append(prop.Modifiers, bindings.ModifierReadonly)
-				if _, isArray := prop.Type.(*bindings.ArrayType); isArray {
-					prop.Type = bindings.OperatorNode(bindings.KeywordReadonly, prop.Type)
-				}
-			}
+			walk.Walk(&readOnlyVisitor{}, node)
 		case *bindings.VariableStatement:
 		case *bindings.Enum:
 			// Enums are immutable by default
+		case *bindings.FunctionDeclaration:
+			// Function arguments and returns are owned by the caller.
 		default:
 			panic("unexpected node type for exporting")
 		}
 	})
 }
 
-// TrimEnumPrefix removes the enum name from the member names.
+// readOnlyVisitor replaces every mutable child type of a node with its
+// immutable version.
+type readOnlyVisitor struct{}
+
+func (v *readOnlyVisitor) Visit(node bindings.Node) walk.Visitor {
+	switch node := node.(type) {
+	case *bindings.Alias:
+		node.Type = readOnlyType(node.Type)
+	case *bindings.PropertySignature:
+		if !slices.Contains(node.Modifiers, bindings.ModifierReadonly) {
+			node.Modifiers = append(node.Modifiers, bindings.ModifierReadonly)
+		}
+		node.Type = readOnlyType(node.Type)
+	case *bindings.ArrayType:
+		node.Node = readOnlyType(node.Node)
+	case *bindings.MethodSignature:
+		node.Type = readOnlyType(node.Type)
+	case *bindings.FunctionType:
+		node.Type = readOnlyType(node.Type)
+	case *bindings.Parameter:
+		node.Type = readOnlyType(node.Type)
+	case *bindings.TupleType:
+		node.Node = readOnlyType(node.Node)
+		for i := range node.Elements {
+			node.Elements[i] = readOnlyType(node.Elements[i])
+		}
+	case *bindings.UnionType:
+		for i := range node.Types {
+			node.Types[i] = readOnlyType(node.Types[i])
+		}
+	case *bindings.TypeIntersection:
+		for i := range node.Types {
+			node.Types[i] = readOnlyType(node.Types[i])
+		}
+	case *bindings.ReferenceType:
+		if isReadOnlyReference(node) {
+			// The argument is already immutable, but the children of the
+			// argument might not be.
+			break
+		}
+		for i := range node.Arguments {
+			node.Arguments[i] = readOnlyType(node.Arguments[i])
+		}
+	case *bindings.HeritageClause, *bindings.TypeParameter:
+		// Changing the inherited types or the generic constraints changes
+		// the meaning of the type.
+		return nil
+	}
+	return v
+}
+
+// readOnlyType returns the immutable version of a type. Only the type itself is
+// changed, the children are handled by the visitor.
+func readOnlyType(node bindings.ExpressionType) bindings.ExpressionType {
+	switch node := node.(type) {
+	case *bindings.ArrayType, *bindings.TupleType:
+		return bindings.OperatorNode(bindings.KeywordReadonly, node)
+	case *bindings.ReferenceType:
+		if node.Name.Name == "Record" && node.Name.Package == nil {
+			return bindings.Reference(bindings.Identifier{Name: "Readonly"}, node)
+		}
+	}
+	return node
+}
+
+func isReadOnlyReference(ref *bindings.ReferenceType) bool {
+	return ref.Name.Name == "Readonly" && ref.Name.Package == nil
+}
+
+// TrimEnumPrefix removes the enum name from the member names. References to
+// the members, like the values of EnumLists, are renamed as well.
 func TrimEnumPrefix(ts *guts.Typescript) {
+	// renamed are the new member names of each enum.
+	renamed := make(map[string]map[string]string)
 	ts.ForEach(func(key string, node bindings.Node) {
 		enum, ok := node.(*bindings.Enum)
 		if !ok {
 			return
 		}
-
+		names := make(map[string]string, len(enum.Members))
 		for _, member := range enum.Members {
-			member.Name = strings.TrimPrefix(member.Name, enum.Name.Name)
+			names[member.Name] = strings.TrimPrefix(member.Name, enum.Name.Name)
+			member.Name = names[member.Name]
 		}
+		renamed[enum.Name.Qualified()] = names
+	})
+
+	ts.ForEach(func(key string, node bindings.Node) {
+		walk.Apply(node, func(c *walk.Cursor) bool {
+			access, ok := c.Node().(*bindings.PropertyAccess)
+			if !ok {
+				return true
+			}
+			if name, ok := renamed[access.Object.Qualified()][access.Name]; ok {
+				access.Name = name
+			}
+			return true
+		}, nil)
 	})
 }
 
@@ -109,9 +200,34 @@ func TrimEnumPrefix(ts *guts.Typescript) {
 // An enum will look like:
 // type EnumString = "bar" | "baz" | "foo" | "qux";
 func EnumAsTypes(ts *guts.Typescript) {
+	enumAsTypes(ts, func(enum *bindings.Enum) bool { return true })
+}
+
+// StringEnumsAsTypes is the same as EnumAsTypes, but only for enums with string
+// values. Numeric enums are left as 'enum', which in typescript also provides a
+// reverse lookup from the value to the member name.
+// enum EnumInt { Foo = 0, Bar = 1 } --> EnumInt[0] === "Foo"
+func StringEnumsAsTypes(ts *guts.Typescript) {
+	enumAsTypes(ts, func(enum *bindings.Enum) bool {
+		for _, member := range enum.Members {
+			literal, ok := member.Value.(*bindings.LiteralType)
+			if !ok {
+				return false
+			}
+			if _, ok := literal.Value.(string); !ok {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func enumAsTypes(ts *guts.Typescript, include func(enum *bindings.Enum) bool) {
+	// values are the member values of each converted enum.
+	values := make(map[string]map[string]bindings.ExpressionType)
 	ts.ForEach(func(key string, node bindings.Node) {
 		enum, ok := node.(*bindings.Enum)
-		if !ok {
+		if !ok || !include(enum) {
 			return
 		}
 
@@ -119,9 +235,12 @@ func EnumAsTypes(ts *guts.Typescript) {
 		union := &bindings.UnionType{
 			Types: make([]bindings.ExpressionType, 0, len(enum.Members)),
 		}
+		members := make(map[string]bindings.ExpressionType, len(enum.Members))
 		for _, member := range enum.Members {
 			union.Types = append(union.Types, member.Value)
+			members[member.Name] = member.Value
 		}
+		values[enum.Name.Qualified()] = members
 
 		// Replace the enum with an alias type
 		ts.ReplaceNode(key, &bindings.Alias{
@@ -131,9 +250,61 @@ func EnumAsTypes(ts *guts.Typescript) {
 			Source:    enum.Source,
 		})
 	})
+
+	// A type has no members, so references to the members are replaced
+	// with the values.
+	ts.ForEach(func(key string, node bindings.Node) {
+		walk.Apply(node, func(c *walk.Cursor) bool {
+			access, ok := c.Node().(*bindings.PropertyAccess)
+			if !ok {
+				return true
+			}
+			if value, ok := values[access.Object.Qualified()][access.Name]; ok && value != nil {
+				c.Replace(bindings.Clone(value))
+			}
+			return true
+		}, nil)
+	})
+}
+
+// EnumAsConstEnums declares enums as 'const enum'. The members are inlined by
+// the typescript compiler, so no object exists at runtime.
+// enum Foo { Bar = "bar" } --> const enum Foo { Bar = "bar" }
+func EnumAsConstEnums(ts *guts.Typescript) {
+	ts.ForEach(func(key string, node bindings.Node) {
+		if enum, ok := node.(*bindings.Enum); ok {
+			enum.Const = true
+		}
+	})
+}
+
+// EnumAsConstObjects replaces enums with a readonly object of the members, and
+// a type of the member values. The values are plain literals, like with
+// EnumAsTypes, but the members can still be referenced at runtime.
+//
+//	export const Foo = {
+//	    Bar: "bar"
+//	} as const;
+//	export type Foo = (typeof Foo)[keyof typeof Foo];
+func EnumAsConstObjects(ts *guts.Typescript) {
+	ts.ForEach(func(key string, node bindings.Node) {
+		enum, ok := node.(*bindings.Enum)
+		if !ok {
+			return
+		}
+		for _, member := range enum.Members {
+			if member.Value == nil {
+				// Implicit values only exist for 'enum'.
+				return
+			}
+		}
+		enum.Object = true
+	})
 }
 
 // EnumLists adds a constant that lists all the values in a given enum.
+// Enums in any style are supported, members of 'enum' and objects from
+// EnumAsConstObjects are referenced by name.
 // Example:
 // type MyEnum = string
 // const (
@@ -141,36 +312,34 @@ func EnumAsTypes(ts *guts.Typescript) {
 // EnumBar = "bar"
 // )
 // const MyEnums: string = ["foo", "bar"] <-- this is added
-// TODO: Enums were changed to use proper enum types. This should be
-// updated to support that. EnumLists only works with EnumAsTypes used first.
 func EnumLists(ts *guts.Typescript) {
 	addNodes := make(map[string]bindings.Node)
+	// enums are the enums of each list name, to find enums that pluralize
+	// to the same name.
+	enums := make(map[string][]string)
 	ts.ForEach(func(key string, node bindings.Node) {
 		// Find the enums, and make a list of values.
-		// Only support primitive types.
-		_, union, ok := isGoEnum(node)
+		enum, values, ok := enumValues(node)
 		if !ok {
 			return
 		}
 
-		values := make([]bindings.ExpressionType, 0, len(union.Types))
-		for _, t := range union.Types {
-			values = append(values, t)
-		}
-
-		name := pluralize(key)
+		// The list is declared alongside the enum, so it shares the same
+		// package and namespace.
+		name := enum
+		name.Name = pluralize(name.Name)
 
-		addNodes[name] = &bindings.VariableStatement{
+		enums[name.Qualified()] = append(enums[name.Qualified()], key)
+		addNodes[name.Qualified()] = &bindings.VariableStatement{
 			Modifiers: []bindings.Modifier{},
 			Declarations: &bindings.VariableDeclarationList{
 				Declarations: []*bindings.VariableDeclaration{
 					{
-						// TODO: Fix this with Identifier's instead of "string"
-						Name:            bindings.Identifier{Name: name},
+						Name:            name,
 						ExclamationMark: false,
 						Type: &bindings.ArrayType{
 							// The type is the enum type
-							Node: bindings.Reference(bindings.Identifier{Name: key}),
+							Node: bindings.Reference(enum),
 						},
 						Initializer: &bindings.ArrayLiteralType{
 							Elements: values,
@@ -184,6 +353,13 @@ func EnumLists(ts *guts.Typescript) {
 	})
 
 	for name, node := range addNodes {
+		if len(enums[name]) > 1 {
+			slices.Sort(enums[name])
+			slog.Warn(fmt.Sprintf("enum list %s cannot be added, multiple enums have the same list name. "+
+				"To generate these enum lists, the name collision must be resolved. ", name),
+				slog.String("enums", strings.Join(enums[name], ", ")))
+			continue
+		}
 		if n, ok := ts.Node(name); ok {
 			slog.Warn(fmt.Sprintf("enum list %s cannot be added, an existing declaration with that name exists. "+
 				"To generate this enum list, the name collision must be resolved. ", name),
@@ -258,44 +434,190 @@ func (h *hasAnyVisitor) Visit(node bindings.Node) walk.Visitor {
 // This happens when a golang pointer is the element type of a slice.
 // Example:
 // GolangType: []*string
-// TsType: (string | null)[] --> (string)[]
-// TODO: Somehow remove the parenthesis from the output type.
-// Might have to change the node from a union type to it's first element.
+// TsType: (string | null)[] --> string[]
 func NullUnionSlices(ts *guts.Typescript) {
 	ts.ForEach(func(key string, node bindings.Node) {
-		walk.Walk(&nullUnionVisitor{}, node)
+		walk.Apply(node, func(c *walk.Cursor) bool {
+			if _, ok := c.Parent().(*bindings.ArrayType); !ok {
+				return true
+			}
+			union, ok := c.Node().(*bindings.UnionType)
+			if !ok || len(union.Types) != 2 {
+				return true
+			}
+
+			// A union of 2 types, one being null.
+			// Replace the union with the non-null type.
+			if i := slices.IndexFunc(union.Types, isNull); i != -1 {
+				c.Replace(union.Types[1-i])
+			}
+			return true
+		}, nil)
 	})
 }
 
-type nullUnionVisitor struct{}
+// NormalizeTypes simplifies unions and intersections using structural
+// equality.
+//   - Nested unions and intersections are flattened.
+//   - Duplicate members are removed.
+//   - Subsumed members are removed, eg: 'any | string' --> 'any', and
+//     '"foo" | string' --> 'string'.
+//   - Single member unions and intersections are replaced by the member.
+//   - Optional fields drop 'null' from their union, if the field is a single
+//     level pointer, slice or map. Golang omits the nil value of fields tagged
+//     with 'omitempty' or 'omitzero', so the field is either missing or not
+//     null. A non-nil pointer to a nil value, like '*[]string', is still
+//     null, as is a nil 'any'.
+//
+// Example:
+// GolangType: **string
+// TsType: string | null | null --> string | null
+func NormalizeTypes(ts *guts.Typescript) {
+	ts.ForEach(func(key string, node bindings.Node) {
+		walk.Apply(node, nil, func(c *walk.Cursor) bool {
+			switch n := c.Node().(type) {
+			case *bindings.UnionType:
+				c.Replace(normalizeUnion(n))
+			case *bindings.TypeIntersection:
+				c.Replace(normalizeIntersection(n))
+			case *bindings.PropertySignature:
+				if !n.QuestionToken || !omitsNull(ts, n) {
+					break
+				}
+				if union, ok := n.Type.(*bindings.UnionType); ok {
+					union.Types = slices.DeleteFunc(union.Types, isNull)
+					n.Type = normalizeUnion(union)
+				}
+			}
+			return true
+		})
+	})
+}
 
-func (v *nullUnionVisitor) Visit(node bindings.Node) walk.Visitor {
-	if array, ok := node.(*bindings.ArrayType); ok {
-		// Is array
-		if union, ok := array.Node.(*bindings.UnionType); ok {
-			hasNull := slices.ContainsFunc(union.Types, func(t bindings.ExpressionType) bool {
-				_, isNull := t.(*bindings.Null)
-				return isNull
-			})
+// omitsNull returns true if the only null value of an optional field is the
+// nil value, which is omitted.
+func omitsNull(ts *guts.Typescript, field *bindings.PropertySignature) bool {
+	goField, ok := ts.GoField(field)
+	if !ok {
+		return false
+	}
+	switch ty := goField.Var.Type().Underlying().(type) {
+	case *types.Slice, *types.Map:
+		return true
+	case *types.Pointer:
+		switch ty.Elem().Underlying().(type) {
+		case *types.Pointer, *types.Slice, *types.Map, *types.Interface:
+			return false
+		}
+		return true
+	}
+	return false
+}
 
-			// With union type
-			if len(union.Types) == 2 && hasNull {
-				// A union of 2 types, one being null
-				// Remove the null type
-				newTypes := make([]bindings.ExpressionType, 0, 1)
-				for _, t := range union.Types {
-					if _, isNull := t.(*bindings.Null); isNull {
-						continue
-					}
-					newTypes = append(newTypes, t)
-				}
-				union.Types = newTypes
+// normalizeUnion expects the members of the union to already be normalized.
+func normalizeUnion(union *bindings.UnionType) bindings.ExpressionType {
+	members := flatten(union.Types, func(t bindings.ExpressionType) ([]bindings.ExpressionType, bool) {
+		u, ok := t.(*bindings.UnionType)
+		if !ok {
+			return nil, false
+		}
+		return u.Types, true
+	})
+
+	// 'any' and 'unknown' subsume all other types.
+	for _, top := range []bindings.LiteralKeyword{bindings.KeywordAny, bindings.KeywordUnknown} {
+		if slices.ContainsFunc(members, isKeyword(top)) {
+			return ptr(top)
+		}
+	}
+
+	// 'never' is the empty union
+	members = slices.DeleteFunc(members, isKeyword(bindings.KeywordNever))
+	members = slices.DeleteFunc(members, func(t bindings.ExpressionType) bool {
+		literal, ok := t.(*bindings.LiteralType)
+		if !ok {
+			return false
+		}
+		keyword, ok := literalKeyword(literal)
+		return ok && slices.ContainsFunc(members, isKeyword(keyword))
+	})
+
+	switch len(members) {
+	case 0:
+		return ptr(bindings.KeywordNever)
+	case 1:
+		return members[0]
+	}
+	union.Types = members
+	return union
+}
+
+// normalizeIntersection expects the members of the intersection to already be
+// normalized.
+func normalizeIntersection(intersection *bindings.TypeIntersection) bindings.ExpressionType {
+	members := flatten(intersection.Types, func(t bindings.ExpressionType) ([]bindings.ExpressionType, bool) {
+		i, ok := t.(*bindings.TypeIntersection)
+		if !ok {
+			return nil, false
+		}
+		return i.Types, true
+	})
+
+	// 'unknown' is the identity of an intersection
+	members = slices.DeleteFunc(members, isKeyword(bindings.KeywordUnknown))
+
+	switch len(members) {
+	case 0:
+		return ptr(bindings.KeywordUnknown)
+	case 1:
+		return members[0]
+	}
+	intersection.Types = members
+	return intersection
+}
 
+// flatten inlines the nested members, and removes duplicates.
+func flatten(types []bindings.ExpressionType, nested func(t bindings.ExpressionType) ([]bindings.ExpressionType, bool)) []bindings.ExpressionType {
+	members := make([]bindings.ExpressionType, 0, len(types))
+	var add func(t bindings.ExpressionType)
+	add = func(t bindings.ExpressionType) {
+		if inner, ok := nested(t); ok {
+			for _, n := range inner {
+				add(n)
 			}
+			return
+		}
+		if slices.ContainsFunc(members, func(m bindings.ExpressionType) bool {
+			return bindings.Equal(m, t)
+		}) {
+			return
 		}
+		members = append(members, t)
 	}
+	for _, t := range types {
+		add(t)
+	}
+	return members
+}
 
-	return v
+func isKeyword(keyword bindings.LiteralKeyword) func(t bindings.ExpressionType) bool {
+	return func(t bindings.ExpressionType) bool {
+		k, ok := t.(*bindings.LiteralKeyword)
+		return ok && *k == keyword
+	}
+}
+
+// literalKeyword returns the keyword type of a literal value.
+func literalKeyword(literal *bindings.LiteralType) (bindings.LiteralKeyword, bool) {
+	switch literal.Value.(type) {
+	case string:
+		return bindings.KeywordString, true
+	case bool:
+		return bindings.KeywordBoolean, true
+	case int, int64, uint64, float64:
+		return bindings.KeywordNumber, true
+	}
+	return "", false
 }
 
 // NotNullMaps assumes all maps will not be null.
@@ -304,34 +626,30 @@ func (v *nullUnionVisitor) Visit(node bindings.Node) walk.Visitor {
 // TsType: Record<string,string> | null --> Record<string,string>
 func NotNullMaps(ts *guts.Typescript) {
 	ts.ForEach(func(key string, node bindings.Node) {
-		walk.Walk(&notNullMaps{}, node)
-	})
-}
-
-type notNullMaps struct{}
-
-func (v *notNullMaps) Visit(node bindings.Node) walk.Visitor {
-	if union, ok := node.(*bindings.UnionType); ok && len(union.Types) == 2 {
-		hasNull := slices.ContainsFunc(union.Types, func(t bindings.ExpressionType) bool {
-			_, isNull := t.(*bindings.Null)
-			return isNull
-		})
+		walk.Apply(node, func(c *walk.Cursor) bool {
+			union, ok := c.Node().(*bindings.UnionType)
+			if !ok || len(union.Types) != 2 || !slices.ContainsFunc(union.Types, isNull) {
+				return true
+			}
 
-		var record bindings.ExpressionType
-		index := slices.IndexFunc(union.Types, func(t bindings.ExpressionType) bool {
-			ref, isRef := t.(*bindings.ReferenceType)
-			if !isRef {
-				return false
+			index := slices.IndexFunc(union.Types, func(t bindings.ExpressionType) bool {
+				ref, isRef := t.(*bindings.ReferenceType)
+				if !isRef {
+					return false
+				}
+				return ref.Name.Name == "Record"
+			})
+			if index != -1 {
+				c.Replace(union.Types[index])
 			}
-			return ref.Name.Name == "Record"
-		})
-		if hasNull && index != -1 {
-			record = union.Types[index]
-			union.Types = []bindings.ExpressionType{record}
-		}
-	}
+			return true
+		}, nil)
+	})
+}
 
-	return v
+func isNull(t bindings.ExpressionType) bool {
+	_, ok := t.(*bindings.Null)
+	return ok
 }
 
 // InterfaceToType converts all interfaces to type aliases.
@@ -346,6 +664,7 @@ func InterfaceToType(ts *guts.Typescript) {
 		// Create a type literal node to represent the interface structure
 		var typeLiteral bindings.ExpressionType = &bindings.TypeLiteralNode{
 			Members: intf.Fields,
+			Methods: intf.Methods,
 		}
 
 		// If the interface has heritage (extends/implements), create an intersection type.
@@ -405,6 +724,31 @@ func isVowel(b byte) bool {
 	return strings.IndexByte("aeiouAEIOU", b) >= 0
 }
 
+// enumValues returns the name and the values of an enum. Aliases are only
+// enums if they are a union of literals of the same type.
+func enumValues(node bindings.Node) (bindings.Identifier, []bindings.ExpressionType, bool) {
+	switch node := node.(type) {
+	case *bindings.Enum:
+		values := make([]bindings.ExpressionType, 0, len(node.Members))
+		for _, member := range node.Members {
+			values = append(values, &bindings.PropertyAccess{Object: node.Name, Name: member.Name})
+		}
+		return node.Name, values, len(values) > 0
+	case *bindings.Alias:
+		alias, union, ok := isGoEnum(node)
+		if !ok {
+			return bindings.Identifier{}, nil, false
+		}
+		// The values are copied, so mutating the list does not mutate the enum.
+		values := make([]bindings.ExpressionType, 0, len(union.Types))
+		for _, t := range union.Types {
+			values = append(values, bindings.Clone(t))
+		}
+		return alias.Name, values, true
+	}
+	return bindings.Identifier{}, nil, false
+}
+
 func isGoEnum(n bindings.Node) (*bindings.Alias, *bindings.UnionType, bool) {
 	al, ok := n.(*bindings.Alias)
 	if !ok {
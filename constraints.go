@@ -0,0 +1,231 @@
+package guts
+
+import (
+	"go/types"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/structtag"
+	"golang.org/x/xerrors"
+
+	"github.com/coder/guts/bindings"
+)
+
+// ConstraintOptions configures how validation struct tags are parsed.
+type ConstraintOptions struct {
+	// Tags are the struct tags with validation rules.
+	// Defaults to 'validate' (go-playground/validator) and 'binding' (gin).
+	Tags []string
+	// RequiredOverridesOptional makes fields with the 'required' rule
+	// non-optional, even if the json tag has 'omitempty'.
+	RequiredOverridesOptional bool
+}
+
+// ValidationConstraints parses go-playground/validator style struct tags into
+// the constraints of each field. The constraints are included as JSDoc tags.
+//
+//	Name string `json:"name" validate:"required,min=1,max=64"`
+//
+// Generates:
+//
+//	/**
+//	 * @minLength 1
+//	 * @maxLength 64
+//	 */
+//	readonly name: string;
+//
+// Rules that cannot be represented, like cross field rules, are ignored.
+func (p *GoParser) ValidationConstraints(opts ConstraintOptions) *GoParser {
+	if len(opts.Tags) == 0 {
+		opts.Tags = []string{"validate", "binding"}
+	}
+	p.constraints = &opts
+	return p
+}
+
+// constraintKind is what the length and limit rules apply to.
+type constraintKind int
+
+const (
+	constraintOther constraintKind = iota
+	constraintString
+	constraintNumber
+	constraintArray
+)
+
+// formatRules are rules that are a json schema string format.
+var formatRules = map[string]string{
+	"email":            "email",
+	"url":              "uri",
+	"http_url":         "uri",
+	"uri":              "uri",
+	"uuid":             "uuid",
+	"uuid4":            "uuid",
+	"uuid_rfc4122":     "uuid",
+	"uuid4_rfc4122":    "uuid",
+	"ipv4":             "ipv4",
+	"ipv6":             "ipv6",
+	"ip":               "ip",
+	"hostname":         "hostname",
+	"hostname_rfc1123": "hostname",
+	"fqdn":             "hostname",
+}
+
+// patternRules are rules that are a regular expression.
+var patternRules = map[string]string{
+	"alpha":       `^[a-zA-Z]+$`,
+	"alphanum":    `^[a-zA-Z0-9]+$`,
+	"numeric":     `^[-+]?[0-9]+(?:\.[0-9]+)?$`,
+	"number":      `^[0-9]+$`,
+	"hexadecimal": `^(0[xX])?[0-9a-fA-F]+$`,
+	"e164":        `^\+[1-9]?[0-9]{7,14}$`,
+}
+
+// oneOfValues matches the values of the 'oneof' rule. Values with spaces are
+// quoted with single quotes.
+var oneOfValues = regexp.MustCompile(`'[^']*'|\S+`)
+
+// parseConstraints returns the constraints from the validation tags of a
+// field. Nil is returned if there are none.
+func parseConstraints(opts ConstraintOptions, ty types.Type, tags *structtag.Tags) (*bindings.Constraints, error) {
+	kind := kindOfConstraint(ty)
+	constraints := &bindings.Constraints{}
+	for _, key := range opts.Tags {
+		tag, err := tags.Get(key)
+		if err != nil {
+			continue
+		}
+
+		// The tag library splits the tag on ',' into a name and options.
+		rules := append([]string{tag.Name}, tag.Options...)
+		for _, rule := range rules {
+			if rule == "dive" || rule == "keys" {
+				// The remaining rules are for the elements.
+				break
+			}
+			if strings.Contains(rule, "|") {
+				// Or'd rules cannot be represented.
+				continue
+			}
+
+			name, param, _ := strings.Cut(rule, "=")
+			err := applyRule(constraints, kind, name, param)
+			if err != nil {
+				return nil, xerrors.Errorf("%s tag rule %q: %w", key, rule, err)
+			}
+		}
+	}
+
+	if constraints.IsEmpty() {
+		return nil, nil
+	}
+	return constraints, nil
+}
+
+func applyRule(c *bindings.Constraints, kind constraintKind, name, param string) error {
+	if format, ok := formatRules[name]; ok {
+		if kind == constraintString && c.Format == "" {
+			c.Format = format
+		}
+		return nil
+	}
+	if pattern, ok := patternRules[name]; ok {
+		setPattern(c, kind, pattern)
+		return nil
+	}
+
+	switch name {
+	case "required":
+		c.Required = true
+	case "min", "gte":
+		return limit(c, kind, param, 0, &c.MinLength, &c.MinItems, &c.Minimum)
+	case "max", "lte":
+		return limit(c, kind, param, 0, &c.MaxLength, &c.MaxItems, &c.Maximum)
+	case "gt":
+		return limit(c, kind, param, 1, &c.MinLength, &c.MinItems, &c.ExclusiveMinimum)
+	case "lt":
+		return limit(c, kind, param, -1, &c.MaxLength, &c.MaxItems, &c.ExclusiveMaximum)
+	case "len":
+		if err := limit(c, kind, param, 0, &c.MinLength, &c.MinItems, &c.Minimum); err != nil {
+			return err
+		}
+		return limit(c, kind, param, 0, &c.MaxLength, &c.MaxItems, &c.Maximum)
+	case "oneof":
+		for _, value := range oneOfValues.FindAllString(param, -1) {
+			value = strings.Trim(value, "'")
+			if kind != constraintNumber {
+				c.OneOf = append(c.OneOf, value)
+				continue
+			}
+			num, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return xerrors.Errorf("invalid number %q: %w", value, err)
+			}
+			c.OneOf = append(c.OneOf, num)
+		}
+	case "startswith":
+		setPattern(c, kind, "^"+regexp.QuoteMeta(param))
+	case "endswith":
+		setPattern(c, kind, regexp.QuoteMeta(param)+"$")
+	case "contains":
+		setPattern(c, kind, regexp.QuoteMeta(param))
+	}
+	return nil
+}
+
+// limit sets the length or value limit of the rule. For lengths, the offset
+// converts an exclusive limit to an inclusive one.
+func limit(c *bindings.Constraints, kind constraintKind, param string, offset int, length, items **int, value **float64) error {
+	switch kind {
+	case constraintString, constraintArray:
+		n, err := strconv.Atoi(param)
+		if err != nil {
+			return xerrors.Errorf("invalid length %q: %w", param, err)
+		}
+		n += offset
+		if kind == constraintString {
+			*length = &n
+		} else {
+			*items = &n
+		}
+	case constraintNumber:
+		n, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			return xerrors.Errorf("invalid number %q: %w", param, err)
+		}
+		*value = &n
+	}
+	return nil
+}
+
+// setPattern sets the pattern of a string. Only the first pattern is kept.
+func setPattern(c *bindings.Constraints, kind constraintKind, pattern string) {
+	if kind == constraintString && c.Pattern == "" {
+		c.Pattern = pattern
+	}
+}
+
+func kindOfConstraint(ty types.Type) constraintKind {
+	// Validation rules apply to the value of pointers.
+	for {
+		ptr, ok := ty.(*types.Pointer)
+		if !ok {
+			break
+		}
+		ty = ptr.Elem()
+	}
+
+	switch under := ty.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case under.Info()&types.IsString > 0:
+			return constraintString
+		case under.Info()&types.IsNumeric > 0:
+			return constraintNumber
+		}
+	case *types.Slice, *types.Array:
+		return constraintArray
+	}
+	return constraintOther
+}
@@ -7,9 +7,10 @@ import (
 	"go/token"
 	"go/types"
 	"log/slog"
+	"maps"
 	"reflect"
 	"regexp"
-	"sort"
+	"slices"
 	"strings"
 
 	"github.com/fatih/structtag"
@@ -37,12 +38,68 @@ type GoParser struct {
 
 	// typeOverrides can override any field type with a custom type.
 	// This needs to be a producer function, as the AST is mutated directly,
-	// and we cannot have shared references.
+	// and we cannot have shared references. Use 'bindings.Clone' to produce
+	// copies of a plain value.
 	// Eg: "time.Time" -> "string"
-	typeOverrides    map[string]TypeOverride
+	typeOverrides map[string]TypeOverride
+	// overrideRules are checked in order, after the exact 'typeOverrides'.
+	overrideRules []OverrideRule
+	// typescriptVM parses the typescript of 'IncludeCustomTypescript'. It is
+	// created once, as creating the vm is slow.
+	typescriptVM *bindings.Bindings
+	// fieldOverrides change single struct fields, keyed by the fully
+	// qualified field path.
+	fieldOverrides   map[string]FieldOverride
 	config           *packages.Config
 	fileSet          *token.FileSet
 	preserveComments bool
+	stringerEnums    bool
+	// int64Policy is the global policy for 64 bit integers, int64Policies
+	// overrides it for specific named types.
+	int64Policy   Int64Policy
+	int64Policies map[string]Int64Policy
+	// generateVariables generates package level variables with constant
+	// initial values.
+	generateVariables bool
+	// functionTypes generates golang functions as typescript function types
+	// when set.
+	functionTypes *FunctionOptions
+	// interfaceMethods generates interfaces with methods as typescript
+	// interfaces with method signatures.
+	interfaceMethods bool
+	// wasmGlobals generates declarations for functions registered with
+	// 'syscall/js'.
+	wasmGlobals bool
+	// brandedTypes makes named scalar types nominal.
+	brandedTypes bool
+	// sealedUnions are interfaces generated as the union of their
+	// implementations, keyed by the fully qualified golang name.
+	// sealedVariants are the discriminator values of each implementation.
+	sealedUnions   map[string]sealedUnion
+	sealedVariants map[string]map[string]string
+	// protobuf generates the protojson shape of protobuf messages.
+	protobuf bool
+	// constraints parses validation struct tags into field constraints when
+	// set.
+	constraints *ConstraintOptions
+	// codecs generates rich variants of types, and the functions that
+	// convert them, when set.
+	codecs *CodecOptions
+	// enumNames caches the text names of integer enums.
+	enumNames map[*types.TypeName]map[int64]string
+
+	// renames are user supplied typescript identifiers, keyed by the fully
+	// qualified golang name.
+	renames          map[string]string
+	prefixCollisions bool
+	namespaces       bool
+	// namespaceNames is the namespace of each included package, keyed by
+	// the package path. It is computed before generating if 'namespaces' is
+	// set.
+	namespaceNames map[string]string
+	// collisionPrefixes is computed before generating if 'prefixCollisions'
+	// is set. The key is the fully qualified golang name.
+	collisionPrefixes map[string]string
 }
 
 // NewGolangParser returns a new GoParser object.
@@ -72,6 +129,9 @@ func NewGolangParser() (*GoParser, error) {
 		referencedTypes: newReferencedTypes(),
 		Prefix:          make(map[string]string),
 		Skips:           make(map[string]struct{}),
+		enumNames:       make(map[*types.TypeName]map[int64]string),
+		renames:         make(map[string]string),
+		int64Policies:   make(map[string]Int64Policy),
 		typeOverrides: map[string]TypeOverride{
 			// Some hard coded defaults
 			"error": func() bindings.ExpressionType {
@@ -109,22 +169,59 @@ type GolangType = string
 // "time.Time": "string"
 func (p *GoParser) IncludeCustom(mappings map[GolangType]GolangType) error {
 	for k, v := range mappings {
-		// Make sure it parses
-		_, err := parseExpression(v)
+		exp, err := parseExpression(v)
 		if err != nil {
 			return fmt.Errorf("failed to parse expression %s: %w", v, err)
 		}
+		p.typeOverrides[k] = func() bindings.ExpressionType {
+			return bindings.Clone(exp)
+		}
+	}
+
+	return nil
+}
 
-		v := v
+// IncludeCustomType overrides golang types with typescript expressions.
+// Every use of an override gets its own copy of the expression, so the
+// expressions can be shared.
+// Eg: "time.Time": ptr(bindings.KeywordString)
+func (p *GoParser) IncludeCustomType(mappings map[GolangType]bindings.ExpressionType) {
+	for k, v := range mappings {
 		p.typeOverrides[k] = func() bindings.ExpressionType {
-			exp, err := parseExpression(v)
-			if err != nil {
-				return ptr(bindings.KeywordUnknown)
-			}
-			return exp
+			return bindings.Clone(v)
+		}
+	}
+}
+
+// IncludeCustomTypescript overrides golang types with typescript type syntax.
+// References in the typescript are not resolved, so they can be to any
+// hand written type. If any mapping fails to parse, none of the mappings are
+// added.
+// Eg: "time.Time": "Date"
+// Eg: "github.com/your/repo/pkg.UserID": `Brand<string, "UserID">`
+func (p *GoParser) IncludeCustomTypescript(mappings map[GolangType]string) error {
+	if p.typescriptVM == nil {
+		b, err := bindings.New()
+		if err != nil {
+			return xerrors.Errorf("init typescript: %w", err)
 		}
+		p.typescriptVM = b
 	}
 
+	parsed := make(map[GolangType]bindings.ExpressionType, len(mappings))
+	for _, k := range slices.Sorted(maps.Keys(mappings)) {
+		exp, err := p.typescriptVM.ParseTypescriptType(mappings[k])
+		if err != nil {
+			return xerrors.Errorf("custom type %q: %w", k, err)
+		}
+		parsed[k] = exp
+	}
+
+	for k, exp := range parsed {
+		p.typeOverrides[k] = func() bindings.ExpressionType {
+			return bindings.Clone(exp)
+		}
+	}
 	return nil
 }
 
@@ -185,17 +282,42 @@ func (p *GoParser) include(directory string, prefix string, reference bool) erro
 func (p *GoParser) ToTypescript() (*Typescript, error) {
 	typescript := &Typescript{
 		typescriptNodes:  make(map[string]*typescriptNode),
+		objects:          make(map[string]types.Object),
+		fields:           make(map[*bindings.PropertySignature]GoField),
 		parsed:           p,
 		skip:             p.Skips,
 		preserveComments: p.preserveComments,
 	}
 
+	if p.namespaces {
+		p.namespaceNames = p.buildNamespaceNames()
+	}
+	if p.prefixCollisions {
+		p.collisionPrefixes = nil // Collisions should not include the prefixes
+		p.collisionPrefixes = p.buildCollisionPrefixes()
+	}
+
+	err := p.checkFieldOverrides()
+	if err != nil {
+		return nil, err
+	}
+
+	unions, variants, err := p.buildSealedUnions()
+	if err != nil {
+		return nil, xerrors.Errorf("sealed unions: %w", err)
+	}
+	p.sealedUnions, p.sealedVariants = unions, variants
+
 	// Parse all go types to the typescript AST
-	err := typescript.parseGolangIdentifiers()
+	err = typescript.parseGolangIdentifiers()
 	if err != nil {
 		return nil, err
 	}
 
+	if collisions := collisions(typescript.collisions); len(collisions) > 0 {
+		return nil, &CollisionError{Collisions: collisions}
+	}
+
 	// Apply any post-processing mutations to the nodes.
 	for key, node := range typescript.typescriptNodes {
 		newNode, err := node.applyMutations()
@@ -212,6 +334,13 @@ func (p *GoParser) ToTypescript() (*Typescript, error) {
 		}
 	}
 
+	if p.codecs != nil {
+		err = typescript.generateCodecs()
+		if err != nil {
+			return nil, xerrors.Errorf("codecs: %w", err)
+		}
+	}
+
 	return typescript, nil
 }
 
@@ -219,8 +348,23 @@ type Typescript struct {
 	// typescriptNodes is a map of typescript nodes that are generated from the
 	// parsed go code. All names should be unique. If non-unique names exist, that
 	// means packages contain the same named types.
+	// If namespaces are used, the key is qualified by the namespace. See
+	// 'Identifier.Qualified'.
 	// TODO: the key "string" should be replaced with "Identifier"
-	typescriptNodes  map[string]*typescriptNode
+	typescriptNodes map[string]*typescriptNode
+	// objects are the golang objects of the generated declarations, keyed
+	// like typescriptNodes.
+	objects map[string]types.Object
+	// fields are the golang struct fields of the generated interface fields.
+	fields map[*bindings.PropertySignature]GoField
+	// owners is the fully qualified golang name that generated each node.
+	owners map[string]string
+	// collisions are any typescript identifiers generated by more than one
+	// golang object.
+	collisions map[string]map[string]struct{}
+	// fieldInt64Policy replaces all int64 policies while a field is
+	// generated. Protobuf message fields are always strings.
+	fieldInt64Policy Int64Policy
 	parsed           *GoParser
 	skip             map[string]struct{}
 	preserveComments bool
@@ -297,6 +441,13 @@ func (ts *Typescript) parseGolangIdentifiers() error {
 			ts.parsed.referencedTypes.MarkGenerated(obj)
 		}
 
+		if ts.parsed.wasmGlobals && !ts.parsed.Reference[pkg.PkgPath] {
+			err := ts.parseWasmGlobals(pkg)
+			if err != nil {
+				return fmt.Errorf("parse wasm globals in %q: %w", pkg.PkgPath, err)
+			}
+		}
+
 		// As long as references other things, we have to keep going.
 		err := ts.parsed.referencedTypes.Remaining(func(obj types.Object) error {
 			err := ts.parse(obj)
@@ -336,7 +487,14 @@ func (ts *Typescript) SetNode(key string, node bindings.Node) error {
 	return nil
 }
 
-func (ts *Typescript) setNode(key string, node typescriptNode) error {
+// setNode adds the node generated by the golang object 'owner', which is the
+// fully qualified golang name.
+func (ts *Typescript) setNode(ident bindings.Identifier, owner string, node typescriptNode) error {
+	key := ident.Qualified()
+	if !ts.claim(ident, owner) {
+		// The collision is reported after all nodes are parsed.
+		return nil
+	}
 	if _, ok := ts.typescriptNodes[key]; ok {
 		return fmt.Errorf("node %q already exists", key)
 	}
@@ -344,7 +502,11 @@ func (ts *Typescript) setNode(key string, node typescriptNode) error {
 	return nil
 }
 
-func (ts *Typescript) updateNode(key string, update func(n *typescriptNode)) {
+func (ts *Typescript) updateNode(ident bindings.Identifier, owner string, update func(n *typescriptNode)) {
+	key := ident.Qualified()
+	if !ts.claim(ident, owner) {
+		return
+	}
 	v, ok := ts.typescriptNodes[key]
 	if !ok {
 		v = &typescriptNode{}
@@ -371,23 +533,13 @@ func (ts *Typescript) ForEach(node func(key string, node bindings.Node)) {
 // Serialize will serialize the typescript AST to typescript code.
 // It sorts all types alphabetically.
 func (ts *Typescript) Serialize() (string, error) {
-	return ts.SerializeInOrder(func(order map[string]bindings.Node) []bindings.Node {
-		names := make([]string, 0, len(order))
-		for k := range order {
-			names = append(names, k)
-		}
-		sort.Strings(names)
-
-		nodes := make([]bindings.Node, 0, len(names))
-		for _, k := range names {
-			nodes = append(nodes, order[k])
-		}
-
-		return nodes
-	})
+	return ts.SerializeInOrder(OrderAlphabetical)
 }
 
-func (ts *Typescript) SerializeInOrder(sort func(nodes map[string]bindings.Node) []bindings.Node) (string, error) {
+// SerializeInOrder will serialize the typescript AST to typescript code, using
+// the order returned by 'sort'. See 'OrderBySource', 'OrderByPackage', and
+// 'OrderTopological' for the built-in orderings.
+func (ts *Typescript) SerializeInOrder(sort SerializeOrder) (string, error) {
 	if ts.serialized {
 		return "", fmt.Errorf("already serialized, create a new TS object to serialize again")
 	}
@@ -403,7 +555,7 @@ func (ts *Typescript) SerializeInOrder(sort func(nodes map[string]bindings.Node)
 	for k, v := range ts.typescriptNodes {
 		nodes[k] = v.Node
 	}
-	order := sort(nodes)
+	order := groupNamespaces(valuesAfterReferences(sort(nodes)))
 
 	var str strings.Builder
 	str.WriteString("// Code generated by 'guts'. DO NOT EDIT.\n\n")
@@ -420,27 +572,68 @@ func (ts *Typescript) SerializeInOrder(sort func(nodes map[string]bindings.Node)
 		}
 		str.WriteString(text + "\n\n")
 	}
+	if hasGlobalNamespace(order) {
+		// 'declare global' is only allowed in a module, and a file is only
+		// a module if it has an import or export.
+		str.WriteString("export {};\n")
+	}
 	return str.String(), nil
 
 }
 
 func (ts *Typescript) parse(obj types.Object) error {
 	objectIdentifier := ts.parsed.Identifier(obj)
+	ts.objects[objectIdentifier.Qualified()] = obj
 
 	switch obj := obj.(type) {
 	// All named types are type declarations
 	case *types.TypeName:
 		// Check for any custom overrides before processing any named types.
-		if custom, ok := ts.parsed.typeOverrides[obj.Type().String()]; ok {
-			return ts.setNode(objectIdentifier.Ref(), typescriptNode{
+		custom, ok, err := ts.customType(obj.Type())
+		if err != nil {
+			return xerrors.Errorf("custom type %q: %w", obj.Type().String(), err)
+		}
+		if ok {
+			return ts.setNode(objectIdentifier, qualifiedName(obj), typescriptNode{
 				Node: &bindings.Alias{
-					Name:   objectIdentifier,
-					Type:   custom(),
-					Source: ts.location(obj),
+					Name:       objectIdentifier,
+					Type:       custom.Value,
+					Parameters: custom.TypeParameters,
+					Source:     ts.location(obj),
 				},
 			})
 		}
 
+		// With branded types, a type defined from an overridden type uses
+		// the override, so it can be branded.
+		// type WorkspaceID uuid.UUID
+		if definedFrom, ok := ts.parsed.definedFrom(obj); ok && ts.parsed.brandedTypes {
+			custom, ok, err := ts.customType(definedFrom)
+			if err != nil {
+				return xerrors.Errorf("custom type %q: %w", definedFrom.String(), err)
+			}
+			if ok {
+				params := custom.TypeParameters
+				if params == nil {
+					params = []*bindings.TypeParameter{}
+				}
+				aliasNode := &bindings.Alias{
+					Name:       objectIdentifier,
+					Modifiers:  []bindings.Modifier{},
+					Type:       ts.brand(obj, custom.Value),
+					Parameters: params,
+					Source:     ts.location(obj),
+				}
+				if ts.preserveComments {
+					cmts := ts.parsed.CommentForObject(obj)
+					aliasNode.AppendComments(cmts)
+				}
+				return ts.setNode(objectIdentifier, qualifiedName(obj), typescriptNode{
+					Node: aliasNode,
+				})
+			}
+		}
+
 		var rhs types.Type
 		switch typedObj := obj.Type().(type) {
 		case *types.Named:
@@ -454,6 +647,11 @@ func (ts *Typescript) parse(obj types.Object) error {
 
 		switch underNamed := rhs.(type) {
 		case *types.Struct:
+			if _, _, ok := isOneofWrapper(obj.Type()); ok && ts.parsed.protobuf {
+				// Oneof wrappers are flattened into the message.
+				return nil
+			}
+
 			// type <Name> struct
 			// Structs are obvious.
 			node, err := ts.buildStruct(obj, underNamed)
@@ -462,10 +660,10 @@ func (ts *Typescript) parse(obj types.Object) error {
 			}
 
 			if ts.preserveComments {
-				cmts := ts.parsed.CommentForObject(obj)
+				cmts := withoutAnnotation(ts.parsed.CommentForObject(obj), discriminatorAnnotation)
 				node.AppendComments(cmts)
 			}
-			return ts.setNode(objectIdentifier.Ref(), typescriptNode{
+			return ts.setNode(objectIdentifier, qualifiedName(obj), typescriptNode{
 				Node: node,
 			})
 		case *types.Basic:
@@ -475,14 +673,25 @@ func (ts *Typescript) parse(obj types.Object) error {
 			if err != nil {
 				return xerrors.Errorf("generate basic %q: %w", objectIdentifier.Ref(), err)
 			}
+			if policy, ok := ts.parsed.int64PolicyFor(obj.Type()); ok {
+				// Named types can have their own policy.
+				rhs = simpleParsedType(ptr(int64Keyword(policy)))
+			}
+			if named, ok := obj.Type().(*types.Named); ok {
+				// Integers that marshal to text are strings in json. Enums
+				// without known names stay numeric, matching their values.
+				if _, ok := ts.parsed.textEnumNames(named); ok {
+					rhs = simpleParsedType(ptr(bindings.KeywordString))
+				}
+			}
 
 			// If this has 'const's, then it is an enum. The enum code will
 			// patch this value to be more specific.
-			ts.updateNode(objectIdentifier.Ref(), func(n *typescriptNode) {
+			ts.updateNode(objectIdentifier, qualifiedName(obj), func(n *typescriptNode) {
 				aliasNode := &bindings.Alias{
 					Name:       objectIdentifier,
 					Modifiers:  []bindings.Modifier{},
-					Type:       rhs.Value,
+					Type:       ts.brand(obj, rhs.Value),
 					Parameters: rhs.TypeParameters,
 					Source:     ts.location(obj),
 				}
@@ -517,10 +726,38 @@ func (ts *Typescript) parse(obj types.Object) error {
 				aliasNode.AppendComments(cmts)
 			}
 
-			return ts.setNode(objectIdentifier.Ref(), typescriptNode{
+			return ts.setNode(objectIdentifier, qualifiedName(obj), typescriptNode{
 				Node: aliasNode,
 			})
 		case *types.Interface:
+			if union, ok := ts.parsed.sealedUnions[qualifiedName(obj)]; ok {
+				// type <Name> interface{ <marker>() }
+				node, err := ts.buildSealedUnion(obj, union)
+				if err != nil {
+					return xerrors.Errorf("generate union %q: %w", objectIdentifier.Ref(), err)
+				}
+				return ts.setNode(objectIdentifier, qualifiedName(obj), typescriptNode{
+					Node: node,
+				})
+			}
+
+			if ts.parsed.interfaceMethods && underNamed.IsMethodSet() && underNamed.NumMethods() > 0 {
+				// type <Name> interface{ <methods> }
+				node, err := ts.buildMethodInterface(obj, underNamed)
+				if err != nil {
+					return xerrors.Errorf("generate %q: %w", objectIdentifier.Ref(), err)
+				}
+
+				if ts.preserveComments {
+					cmts := ts.parsed.CommentForObject(obj)
+					node.AppendComments(cmts)
+				}
+
+				return ts.setNode(objectIdentifier, qualifiedName(obj), typescriptNode{
+					Node: node,
+				})
+			}
+
 			// Interfaces are used as generics. Non-generic interfaces are
 			// not supported.
 			if underNamed.NumEmbeddeds() == 1 {
@@ -539,7 +776,7 @@ func (ts *Typescript) parse(obj types.Object) error {
 				if err != nil {
 					return xerrors.Errorf("generate union %q: %w", objectIdentifier.Ref(), err)
 				}
-				return ts.setNode(objectIdentifier.Ref(), typescriptNode{
+				return ts.setNode(objectIdentifier, qualifiedName(obj), typescriptNode{
 					Node: block,
 				})
 			}
@@ -555,7 +792,7 @@ func (ts *Typescript) parse(obj types.Object) error {
 				// A typed `any` is still a type. A strange one to use, but still valid.
 				// TODO: This has not been fully investigated. This line should only be triggered
 				//  on simple `any` types. If this generates something more complex, this will be wrong.
-				ts.updateNode(objectIdentifier.Ref(), func(n *typescriptNode) {
+				ts.updateNode(objectIdentifier, qualifiedName(obj), func(n *typescriptNode) {
 					n.Node = &bindings.Alias{
 						Name:       objectIdentifier,
 						Modifiers:  []bindings.Modifier{},
@@ -569,8 +806,39 @@ func (ts *Typescript) parse(obj types.Object) error {
 
 			return xerrors.Errorf("interface %q is not a union, has %d embeds and unsupported", objectIdentifier.Ref(), underNamed.NumEmbeddeds())
 		case *types.Signature:
-			// Ignore named functions.
-			return nil
+			if ts.parsed.functionTypes == nil {
+				// Ignore named functions.
+				return nil
+			}
+
+			// type <Name> func(<params>) <results>
+			ty, err := ts.typescriptType(underNamed)
+			if err != nil {
+				return xerrors.Errorf("(func) generate %q: %w", objectIdentifier.Ref(), err)
+			}
+
+			aliasNode := &bindings.Alias{
+				Name:       objectIdentifier,
+				Modifiers:  []bindings.Modifier{},
+				Type:       ty.Value,
+				Parameters: ty.TypeParameters,
+				Source:     ts.location(obj),
+			}
+
+			if ts.preserveComments {
+				cmts := ts.parsed.CommentForObject(obj)
+				aliasNode.AppendComments(cmts)
+			}
+			for _, c := range ty.RaisedComments {
+				aliasNode.LeadingComment(c)
+			}
+			for _, tag := range ty.RaisedTags {
+				aliasNode.JSDocTag(tag)
+			}
+
+			return ts.setNode(objectIdentifier, qualifiedName(obj), typescriptNode{
+				Node: aliasNode,
+			})
 		default:
 			// If you hit this error, you added a new unsupported named type.
 			// The easiest way to solve this is add a new case above with
@@ -579,7 +847,23 @@ func (ts *Typescript) parse(obj types.Object) error {
 		}
 	case *types.Var:
 		// TODO: Are any enums var declarations? This is also codersdk.Me.
-		return nil // Maybe we should treat these like consts?
+		if !ts.parsed.generateVariables {
+			return nil
+		}
+
+		stmt, ok, err := ts.variableDeclaration(obj)
+		if err != nil {
+			return xerrors.Errorf("var %q: %w", objectIdentifier.Ref(), err)
+		}
+		if !ok {
+			return nil
+		}
+		if ts.preserveComments {
+			stmt.AppendComments(ts.parsed.CommentForObject(obj))
+		}
+		return ts.setNode(objectIdentifier, qualifiedName(obj), typescriptNode{
+			Node: stmt,
+		})
 	case *types.Const:
 		type constMethods interface {
 			Obj() *types.TypeName
@@ -605,7 +889,7 @@ func (ts *Typescript) parse(obj types.Object) error {
 						cnst.AppendComments(cmts)
 					}
 
-					return ts.setNode(objectIdentifier.Ref(), typescriptNode{
+					return ts.setNode(objectIdentifier, qualifiedName(obj), typescriptNode{
 						Node: cnst,
 					})
 				}
@@ -632,11 +916,15 @@ func (ts *Typescript) parse(obj types.Object) error {
 		if err != nil {
 			return xerrors.Errorf("const %q: %w", objectIdentifier.Ref(), err)
 		}
+		// Integer enums can be marshaled as their text names.
+		if text, ok := ts.parsed.enumTextValue(obj); ok {
+			constValue = text
+		}
 
 		// This is a little hacky, but we need to add the enum to the Alias
 		// type. However, the order types are parsed is not guaranteed, so we
 		// add the enum to the Alias as a post-processing step.
-		ts.updateNode(enumObjName.Ref(), func(n *typescriptNode) {
+		ts.updateNode(enumObjName, qualifiedName(use.Obj()), func(n *typescriptNode) {
 			member := &bindings.EnumMember{
 				Name:  obj.Name(),
 				Value: constValue,
@@ -645,7 +933,7 @@ func (ts *Typescript) parse(obj types.Object) error {
 				cmts := ts.parsed.CommentForObject(obj)
 				member.AppendComments(cmts)
 			}
-			n.AddEnum(member)
+			n.AddEnum(member, obj.Pos())
 		})
 		return nil
 	case *types.Func:
@@ -654,8 +942,6 @@ func (ts *Typescript) parse(obj types.Object) error {
 	default:
 		return xerrors.Errorf("unsupported object type %T", obj)
 	}
-
-	return xerrors.Errorf("should never hit this, obj with type %T", obj)
 }
 
 func (ts *Typescript) constantDeclaration(obj *types.Const) (*bindings.VariableStatement, error) {
@@ -680,14 +966,13 @@ func (ts *Typescript) constantDeclaration(obj *types.Const) (*bindings.VariableS
 	}, nil
 }
 
-func (ts *Typescript) constantValue(obj *types.Const) (*bindings.LiteralType, error) {
+func (ts *Typescript) constantValue(obj *types.Const) (bindings.ExpressionType, error) {
 	var constValue bindings.LiteralType
 	switch obj.Val().Kind() {
 	case constant.String:
 		constValue.Value = constant.StringVal(obj.Val())
 	case constant.Int:
-		// TODO: might want to check this
-		constValue.Value, _ = constant.Int64Val(obj.Val())
+		return ts.integerValue(obj.Name(), obj.Type(), obj.Val())
 	case constant.Float:
 		constValue.Value, _ = constant.Float64Val(obj.Val())
 	case constant.Bool:
@@ -794,6 +1079,21 @@ func (ts *Typescript) buildStruct(obj types.Object, st *types.Struct) (*bindings
 			continue
 		}
 
+		if ts.parsed.protobuf {
+			// Message fields are encoded with protojson, not json.
+			props, goFields, err := ts.protobufFields(field, st.Tag(i))
+			if err != nil {
+				return tsi, xerrors.Errorf("protobuf field %q: %w", field.Name(), err)
+			}
+			if props != nil {
+				for j, prop := range props {
+					ts.fields[prop] = goFields[j]
+				}
+				tsi.Fields = append(tsi.Fields, props...)
+				continue
+			}
+		}
+
 		// Create a new field in the intermediate typescript representation.
 		tsField := &bindings.PropertySignature{
 			Name:          field.Name(),
@@ -819,13 +1119,42 @@ func (ts *Typescript) buildStruct(obj types.Object, st *types.Struct) (*bindings
 			}
 		}
 
-		// Infer the type.
-		tsType, err := ts.typescriptType(field.Type())
-		if err != nil {
-			return tsi, xerrors.Errorf("typescript type: %w", err)
+		// Infer the type, unless the field has a custom type.
+		override, overridden := ts.parsed.fieldOverride(obj, field)
+		tsType := parsedType{}
+		if overridden && override.Type != nil {
+			tsType.Value = override.Type()
+		} else {
+			tsType, err = ts.typescriptType(field.Type())
+			if err != nil {
+				return tsi, xerrors.Errorf("typescript type: %w", err)
+			}
 		}
 		tsField.Type = tsType.Value
+		if !overridden || override.Type == nil {
+			if jsonTag != nil && jsonTag.HasOption("string") {
+				// The ',string' option encodes numbers and booleans as strings.
+				if str, ok := stringOptionType(field.Type()); ok {
+					tsField.Type = str
+				}
+			}
+			if literal, ok := ts.parsed.discriminator(obj, tsField.Name); ok {
+				// The discriminator of a sealed union variant is always the same value.
+				tsField.Type = literal
+			}
+		}
 		tsi.Parameters = append(tsi.Parameters, tsType.TypeParameters...)
+
+		if opts := ts.parsed.constraints; opts != nil {
+			constraints, err := parseConstraints(*opts, field.Type(), tags)
+			if err != nil {
+				return tsi, xerrors.Errorf("field %q constraints: %w", field.Name(), err)
+			}
+			tsField.Constraints = constraints
+			if constraints != nil && constraints.Required && opts.RequiredOverridesOptional {
+				tsField.QuestionToken = false
+			}
+		}
 		// TODO: Better handle comments. The raised comments should probably be set to
 		//   empty after consumed?
 		for _, c := range tsType.RaisedComments {
@@ -846,8 +1175,17 @@ func (ts *Typescript) buildStruct(obj types.Object, st *types.Struct) (*bindings
 			cmts := ts.parsed.CommentForObject(field)
 			tsField.AppendComments(cmts)
 		}
+		for _, tag := range tsType.RaisedTags {
+			tsField.JSDocTag(tag)
+		}
+		if overridden {
+			override.apply(tsField)
+		}
+		ts.fields[tsField] = GoField{Var: field, Tag: reflect.StructTag(st.Tag(i))}
 		tsi.Fields = append(tsi.Fields, tsField)
 	}
+	// The discriminator is first, as if it was declared on the variant.
+	tsi.Fields = append(ts.promotedDiscriminators(obj, st, tsi.Fields), tsi.Fields...)
 
 	simple, err := bindings.Simplify(tsi.Parameters)
 	if err != nil {
@@ -865,6 +1203,9 @@ type parsedType struct {
 	// RaisedComments exists to add comments to the first parent that is willing
 	// to accept them. It is for formatting purposes.
 	RaisedComments []string
+	// RaisedTags are JSDoc tags raised the same way as RaisedComments. They are
+	// included in the JSDoc block of the parent, rather than as line comments.
+	RaisedTags []string
 }
 
 func simpleParsedType(et bindings.ExpressionType) parsedType {
@@ -881,21 +1222,27 @@ func (p parsedType) WithComments(comments ...string) parsedType {
 // TODO: Return comments?
 func (ts *Typescript) typescriptType(ty types.Type) (parsedType, error) {
 	// No matter what the type is, if we have some custom override, always use that.
-	custom, ok := ts.parsed.typeOverrides[ty.String()]
+	custom, ok, err := ts.customType(ty)
+	if err != nil {
+		return parsedType{}, xerrors.Errorf("custom type %q: %w", ty.String(), err)
+	}
 	if ok {
-		return parsedType{
-			Value: custom(),
-		}, nil
+		return custom, nil
 	}
 
 	switch ty := ty.(type) {
 	case *types.Signature:
-		// TODO: Handle functions better
+		if ts.parsed.functionTypes != nil {
+			return ts.functionType(ty, *ts.parsed.functionTypes)
+		}
 		return simpleParsedType(ptr(bindings.KeywordUnknown)).
 			WithComments("Function type detected, and unsupported. Leaving the type as unknown"), nil
 	case *types.Basic:
 		bs := ty
 		// All basic literals (string, bool, int, etc).
+		if policy, ok := ts.int64PolicyFor(bs); ok {
+			return simpleParsedType(ptr(int64Keyword(policy))), nil
+		}
 		switch {
 		case bs.Info()&types.IsNumeric > 0:
 			return simpleParsedType(ptr(bindings.KeywordNumber)), nil
@@ -951,6 +1298,7 @@ func (ts *Typescript) typescriptType(ty types.Type) (parsedType, error) {
 			Value:          bindings.Union(RecordReference(keyType.Value, valueType.Value), &bindings.Null{}),
 			TypeParameters: tp,
 			RaisedComments: append(keyType.RaisedComments, valueType.RaisedComments...),
+			RaisedTags:     append(keyType.RaisedTags, valueType.RaisedTags...),
 		}
 		return parsed, nil
 	case *types.Array:
@@ -970,6 +1318,7 @@ func (ts *Typescript) typescriptType(ty types.Type) (parsedType, error) {
 			Value:          bindings.HomogeneousTuple(int(ty.Len()), underlying.Value),
 			TypeParameters: underlying.TypeParameters,
 			RaisedComments: underlying.RaisedComments,
+			RaisedTags:     underlying.RaisedTags,
 		}, nil
 	case *types.Slice:
 		//// Slice/Arrays are pretty much the same.
@@ -995,6 +1344,7 @@ func (ts *Typescript) typescriptType(ty types.Type) (parsedType, error) {
 				Value:          bindings.Array(underlying.Value),
 				TypeParameters: underlying.TypeParameters,
 				RaisedComments: underlying.RaisedComments,
+				RaisedTags:     underlying.RaisedTags,
 			}, nil
 		}
 	case *types.Named:
@@ -1006,11 +1356,12 @@ func (ts *Typescript) typescriptType(ty types.Type) (parsedType, error) {
 		// We would need to add more logic to determine this, but for now
 		// just hard code them.
 		// TODO: Allow comments here
-		custom, ok := ts.parsed.typeOverrides[n.String()]
+		custom, ok, err := ts.customType(n)
+		if err != nil {
+			return parsedType{}, xerrors.Errorf("custom type %q: %w", n.String(), err)
+		}
 		if ok {
-			return parsedType{
-				Value: custom(),
-			}, nil
+			return custom, nil
 		}
 
 		// If it is not a custom mapping, we should assume the type is
@@ -1033,6 +1384,7 @@ func (ts *Typescript) typescriptType(ty types.Type) (parsedType, error) {
 				exprArgs = append(exprArgs, arg.Value)
 				parsed.TypeParameters = append(parsed.TypeParameters, arg.TypeParameters...)
 				parsed.RaisedComments = append(parsed.RaisedComments, arg.RaisedComments...)
+				parsed.RaisedTags = append(parsed.RaisedTags, arg.RaisedTags...)
 			}
 			parsed.Value = bindings.Reference(ts.parsed.Identifier(ref), exprArgs...)
 
@@ -1256,15 +1608,34 @@ func (p *GoParser) lookupNamedReference(n *types.Named) (types.Object, bool) {
 	return obj, true
 }
 
-// ObjectName returns the name of the object including any prefixes defined by
-// the config.
+// Identifier returns the name of the object including any prefixes and
+// renames defined by the config.
 func (p *GoParser) Identifier(obj types.Object) bindings.Identifier {
 	name := obj.Name()
 	prefix := p.Prefix[obj.Pkg().Path()]
+
+	namespace := ""
+
+	// Only package level objects can be renamed. Type parameters share the
+	// same qualified name.
+	if obj.Parent() == obj.Pkg().Scope() {
+		qualified := qualifiedName(obj)
+		if rename, ok := p.renames[qualified]; ok {
+			name = rename
+		} else if collisionPrefix, ok := p.collisionPrefixes[qualified]; ok {
+			prefix += collisionPrefix
+		}
+
+		if p.namespaces {
+			namespace = p.namespaceName(obj.Pkg())
+		}
+	}
+
 	return bindings.Identifier{
-		Name:    name,
-		Prefix:  prefix,
-		Package: obj.Pkg(),
+		Name:      name,
+		Prefix:    prefix,
+		Package:   obj.Pkg(),
+		Namespace: namespace,
 	}
 }
 
@@ -9,14 +9,17 @@ package guts_test
 import (
 	"flag"
 	"fmt"
+	"go/types"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/coder/guts"
+	"github.com/coder/guts/bindings"
 	"github.com/coder/guts/config"
 )
 
@@ -80,6 +83,11 @@ func TestGeneration(t *testing.T) {
 			gen.PreserveComments()
 
 			dir := filepath.Join(".", "testdata", f.Name())
+			if dir == "testdata/wasm" || dir == "testdata/wasmonly" {
+				// Must be set before loading, as it changes the build target.
+				err = gen.WasmGlobals()
+				require.NoError(t, err)
+			}
 			err = gen.IncludeGenerate("./" + dir)
 			require.NoErrorf(t, err, "include %q", dir)
 
@@ -90,14 +98,112 @@ func TestGeneration(t *testing.T) {
 			case "testdata/excludecustom":
 				err = gen.ExcludeCustom("github.com/coder/guts/testdata/excludecustom.Secret")
 				require.NoErrorf(t, err, "exclude %q", dir)
+			case "testdata/collisions":
+				err = gen.IncludeGenerate("github.com/coder/guts/testdata/collisions/other")
+				require.NoError(t, err)
+				gen.GenerateVariables()
+				gen.PrefixCollisions()
+				err = gen.RenameTypes(map[guts.GolangType]string{
+					"github.com/coder/guts/testdata/collisions/other.Rename": "OtherRenamed",
+				})
+				require.NoError(t, err)
+			case "testdata/namespaces":
+				err = gen.IncludeGenerate("github.com/coder/guts/testdata/namespaces/account")
+				require.NoError(t, err)
+				err = gen.IncludeGenerate("github.com/coder/guts/testdata/namespaces/legacy/account")
+				require.NoError(t, err)
+				err = gen.IncludeGenerate("github.com/coder/guts/testdata/namespaces/global")
+				require.NoError(t, err)
+				gen.PackageNamespaces()
+			case "testdata/stringerenums":
+				gen.StringerEnums()
+			case "testdata/variables", "testdata/literals":
+				gen.GenerateVariables()
+			case "testdata/int64policy":
+				gen.GenerateVariables()
+				err = gen.Int64As(guts.Int64BigInt)
+				require.NoError(t, err)
+				err = gen.Int64TypesAs(map[guts.GolangType]guts.Int64Policy{
+					"github.com/coder/guts/testdata/int64policy.ID": guts.Int64String,
+				})
+				require.NoError(t, err)
+			case "testdata/functypes":
+				gen.FunctionTypes(guts.FunctionOptions{
+					Errors: guts.ErrorReturnsThrow,
+					Async:  true,
+				})
+			case "testdata/methods":
+				gen.InterfaceMethods()
 			case "testdata/alias":
 				err = gen.IncludeCustom(map[guts.GolangType]guts.GolangType{
 					"github.com/coder/guts/testdata/alias.RemappedAlias": "string",
 				})
 				require.NoError(t, err)
+			case "testdata/branded", "testdata/formats":
+				gen.BrandedTypes()
+			case "testdata/protobuf":
+				gen.Protobuf()
+			case "testdata/constraints":
+				gen.ValidationConstraints(guts.ConstraintOptions{RequiredOverridesOptional: true})
+			case "testdata/override":
+				err = gen.IncludeCustomTypescript(map[guts.GolangType]string{
+					"github.com/coder/guts/testdata/override.Pixels":  "`${number}px`",
+					"github.com/coder/guts/testdata/override.Created": "Date | null",
+					"github.com/coder/guts/testdata/override.UserID":  `Brand<string, "UserID">`,
+				})
+				require.NoError(t, err)
+			case "testdata/overriderules":
+				// Types defined from overridden types only use the override
+				// when branded.
+				gen.BrandedTypes()
+				nullable, err := guts.OverrideGlob("database/sql.Null", func(args ...bindings.ExpressionType) bindings.ExpressionType {
+					return bindings.Union(args[0], &bindings.Null{})
+				})
+				require.NoError(t, err)
+				ids, err := guts.OverrideGlob("github.com/coder/guts/testdata/overriderules.DB*", func(...bindings.ExpressionType) bindings.ExpressionType {
+					return config.OverrideLiteral(bindings.KeywordNumber)()
+				})
+				require.NoError(t, err)
+				optional, err := guts.OverrideRegex(`\.Optional$`, func(args ...bindings.ExpressionType) bindings.ExpressionType {
+					return bindings.Union(args[0], config.OverrideLiteral(bindings.KeywordUndefined)())
+				})
+				require.NoError(t, err)
+				gen.IncludeOverrideRules(nullable, ids, optional, guts.OverridePredicate(func(ty types.Type) (guts.TypeOverride, bool) {
+					// Stringers are sent as their text.
+					if _, ok := ty.(*types.Named); !ok || types.NewMethodSet(ty).Lookup(nil, "String") == nil {
+						return nil, false
+					}
+					return config.OverrideLiteral(bindings.KeywordString), true
+				}))
+			case "testdata/fieldoverrides":
+				yes, no := true, false
+				gen.OverrideFields(map[string]guts.FieldOverride{
+					"github.com/coder/guts/testdata/fieldoverrides.Workspace.Settings": {
+						Type: config.OverrideType(bindings.Reference(bindings.Identifier{Name: "WorkspaceSettings"})),
+					},
+					"github.com/coder/guts/testdata/fieldoverrides.Workspace.Owner":   {Nullable: &no, Optional: &yes},
+					"github.com/coder/guts/testdata/fieldoverrides.Workspace.Deleted": {Optional: &no, Nullable: &yes},
+					"github.com/coder/guts/testdata/fieldoverrides.Workspace.Legacy":  {Name: "legacyName", ReadOnly: &yes},
+					"github.com/coder/guts/testdata/fieldoverrides.Workspace.Count":   {Type: config.OverrideLiteral(bindings.KeywordNumber)},
+				})
+			case "testdata/codecs":
+				// Precise bigints need the integers as strings.
+				err = gen.Int64As(guts.Int64String)
+				require.NoError(t, err)
+				gen.Codecs(guts.CodecOptions{
+					Types:  config.RichTypes(),
+					BigInt: true,
+					Maps:   true,
+				})
 			}
 
 			gen.IncludeCustomDeclaration(config.StandardMappings())
+			switch dir {
+			case "testdata/branded":
+				gen.IncludeCustomDeclaration(config.FormatMappings(config.FormatTemplate))
+			case "testdata/formats":
+				gen.IncludeCustomDeclaration(config.FormatMappings(config.FormatBranded))
+			}
 
 			ts, err := gen.ToTypescript()
 			require.NoError(t, err, "to typescript")
@@ -121,6 +227,12 @@ func TestGeneration(t *testing.T) {
 						mutations = append(mutations, config.NotNullMaps)
 					case "EnumAsTypes":
 						mutations = append(mutations, config.EnumAsTypes)
+					case "StringEnumsAsTypes":
+						mutations = append(mutations, config.StringEnumsAsTypes)
+					case "EnumAsConstEnums":
+						mutations = append(mutations, config.EnumAsConstEnums)
+					case "EnumAsConstObjects":
+						mutations = append(mutations, config.EnumAsConstObjects)
 					case "EnumLists":
 						mutations = append(mutations, config.EnumLists)
 					case "ExportTypes":
@@ -129,6 +241,8 @@ func TestGeneration(t *testing.T) {
 						mutations = append(mutations, config.ReadOnly)
 					case "NullUnionSlices":
 						mutations = append(mutations, config.NullUnionSlices)
+					case "NormalizeTypes":
+						mutations = append(mutations, config.NormalizeTypes)
 					case "TrimEnumPrefix":
 						mutations = append(mutations, config.TrimEnumPrefix)
 					case "InterfaceToType":
@@ -191,3 +305,369 @@ func TestNotNullMaps(t *testing.T) {
 	// Not perfect, this asserts if the record is a nullable type.
 	require.Contains(t, output, "SimpleMap: Record<string, string>;", "no nullable Record")
 }
+
+func TestSerializeOrder(t *testing.T) {
+	t.Parallel()
+
+	declaration := regexp.MustCompile(`(?m)^(?:export )?(?:interface|type|enum|const) (\w+)`)
+	cases := []struct {
+		name  string
+		order guts.SerializeOrder
+		want  []string
+	}{
+		{
+			name:  "Alphabetical",
+			order: guts.OrderAlphabetical,
+			want:  []string{"Bar", "Buzz", "Comparable", "Foo", "FooBarPtr", "FooBuzz", "GenBar"},
+		},
+		{
+			name:  "Source",
+			order: guts.OrderBySource,
+			want:  []string{"Foo", "FooBarPtr", "Bar", "GenBar", "FooBuzz", "Buzz", "Comparable"},
+		},
+		{
+			name:  "Package",
+			order: guts.OrderByPackage,
+			want:  []string{"Foo", "FooBarPtr", "Bar", "GenBar", "FooBuzz", "Buzz", "Comparable"},
+		},
+		{
+			name:  "Topological",
+			order: guts.OrderTopological,
+			want:  []string{"Bar", "Comparable", "GenBar", "Foo", "FooBarPtr", "Buzz", "FooBuzz"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			gen, err := guts.NewGolangParser()
+			require.NoError(t, err, "new convert")
+
+			err = gen.IncludeGenerate("./testdata/inheritance")
+			require.NoError(t, err, "include")
+
+			ts, err := gen.ToTypescript()
+			require.NoError(t, err, "to typescript")
+
+			output, err := ts.SerializeInOrder(c.order)
+			require.NoError(t, err, "serialize")
+
+			var names []string
+			for _, match := range declaration.FindAllStringSubmatch(output, -1) {
+				names = append(names, match[1])
+			}
+			require.Equal(t, c.want, names)
+		})
+	}
+}
+
+func TestEnumLists(t *testing.T) {
+	t.Parallel()
+
+	gen, err := guts.NewGolangParser()
+	require.NoError(t, err, "new convert")
+
+	err = gen.IncludeGenerate("./testdata/constobjects")
+	require.NoError(t, err, "include")
+
+	ts, err := gen.ToTypescript()
+	require.NoError(t, err, "to typescript")
+
+	ts.ApplyMutations(config.EnumLists, config.EnumAsConstObjects)
+
+	// Lists are keyed by their name, like all declarations.
+	node, ok := ts.Node("Colors")
+	require.True(t, ok)
+	require.IsType(t, &bindings.VariableStatement{}, node)
+
+	node, ok = ts.Node("Color")
+	require.True(t, ok)
+	require.True(t, node.(*bindings.Enum).Object)
+
+	// Enums with the same list name have no lists.
+	_, ok = ts.Node("Matches")
+	require.False(t, ok)
+
+	// The list references the object, so it is declared after it.
+	output, err := ts.Serialize()
+	require.NoError(t, err, "serialize")
+	require.Less(t, strings.Index(output, "const Priority ="), strings.Index(output, "const Priorities"))
+}
+
+func TestGoMetadata(t *testing.T) {
+	t.Parallel()
+
+	gen, err := guts.NewGolangParser()
+	require.NoError(t, err, "new convert")
+
+	err = gen.IncludeGenerate("./testdata/fieldoverrides")
+	require.NoError(t, err, "include")
+
+	ts, err := gen.ToTypescript()
+	require.NoError(t, err, "to typescript")
+
+	obj, ok := ts.GoObject("Workspace")
+	require.True(t, ok)
+	require.Equal(t, "github.com/coder/guts/testdata/fieldoverrides.Workspace", obj.Type().String())
+
+	_, ok = ts.GoObject("Missing")
+	require.False(t, ok)
+
+	// A mutation can use the golang field, and its struct tags.
+	tags := make(map[string]string)
+	ts.ApplyMutations(func(ts *guts.Typescript) {
+		node, ok := ts.Node("Workspace")
+		require.True(t, ok)
+		for _, field := range node.(*bindings.Interface).Fields {
+			goField, ok := ts.GoField(field)
+			require.True(t, ok, field.Name)
+			tags[goField.Var.Name()] = goField.Tag.Get("json")
+		}
+	})
+	require.Equal(t, map[string]string{
+		"Count":    "count,string",
+		"Name":     "name",
+		"Settings": "settings",
+		"Owner":    "owner",
+		"Deleted":  "deleted,omitempty",
+		"Legacy":   "legacy_name",
+		"Other":    "other",
+	}, tags)
+
+	_, ok = ts.GoField(&bindings.PropertySignature{Name: "name"})
+	require.False(t, ok)
+}
+
+func TestGoMetadataOneof(t *testing.T) {
+	t.Parallel()
+
+	gen, err := guts.NewGolangParser()
+	require.NoError(t, err, "new convert")
+
+	err = gen.IncludeGenerate("./testdata/protobuf")
+	require.NoError(t, err, "include")
+	gen.Protobuf()
+
+	ts, err := gen.ToTypescript()
+	require.NoError(t, err, "to typescript")
+
+	// Oneof options are generated from the fields of their wrappers.
+	fields := make(map[string]string)
+	node, ok := ts.Node("User")
+	require.True(t, ok)
+	for _, field := range node.(*bindings.Interface).Fields {
+		goField, ok := ts.GoField(field)
+		require.True(t, ok, field.Name)
+		fields[field.Name] = goField.Var.Name() + " " + goField.Tag.Get("protobuf")
+	}
+	require.Equal(t, "Email bytes,8,opt,name=email,proto3,oneof", fields["email"])
+	require.Equal(t, "Phone bytes,9,opt,name=phone,proto3,oneof", fields["phone"])
+}
+
+func TestProtobufInt64Policy(t *testing.T) {
+	t.Parallel()
+
+	for _, before := range []bool{true, false} {
+		t.Run(fmt.Sprintf("PolicyBeforeProtobuf=%t", before), func(t *testing.T) {
+			t.Parallel()
+
+			gen, err := guts.NewGolangParser()
+			require.NoError(t, err, "new convert")
+
+			err = gen.IncludeGenerate("./testdata/protobuf")
+			require.NoError(t, err, "include")
+			err = gen.IncludeGenerate("./testdata/int64policy")
+			require.NoError(t, err, "include")
+
+			// The order does not matter.
+			if before {
+				require.NoError(t, gen.Int64As(guts.Int64BigInt))
+				gen.Protobuf()
+			} else {
+				gen.Protobuf()
+				require.NoError(t, gen.Int64As(guts.Int64BigInt))
+			}
+
+			ts, err := gen.ToTypescript()
+			require.NoError(t, err, "to typescript")
+			output, err := ts.Serialize()
+			require.NoError(t, err, "serialize")
+
+			// Message fields are always strings in protojson.
+			require.Contains(t, output, "id?: string;")
+			require.Contains(t, output, "memberCount?: string;")
+			// Other structs keep the policy.
+			require.Contains(t, output, "count: bigint;")
+			require.Contains(t, output, "size: bigint;")
+		})
+	}
+}
+
+func TestUnknownFieldOverrides(t *testing.T) {
+	t.Parallel()
+
+	gen, err := guts.NewGolangParser()
+	require.NoError(t, err, "new convert")
+
+	err = gen.IncludeGenerate("./testdata/fieldoverrides")
+	require.NoError(t, err, "include")
+
+	gen.OverrideFields(map[string]guts.FieldOverride{
+		"github.com/coder/guts/testdata/fieldoverrides.Workspace.Name":     {},
+		"github.com/coder/guts/testdata/fieldoverrides.Workspace.Renamed":  {},
+		"github.com/coder/guts/testdata/fieldoverrides.Missing.Name":       {},
+		"github.com/coder/guts/testdata/notincluded.Workspace.Name":        {},
+		"github.com/coder/guts/testdata/fieldoverrides.WorkspaceSettings":  {},
+		"github.com/coder/guts/testdata/fieldoverrides.Workspace.Secret":   {},
+		"github.com/coder/guts/testdata/fieldoverrides.Workspace.internal": {},
+	})
+	_, err = gen.ToTypescript()
+	require.ErrorContains(t, err, "unknown field overrides: "+strings.Join([]string{
+		"github.com/coder/guts/testdata/fieldoverrides.Missing.Name",
+		"github.com/coder/guts/testdata/fieldoverrides.Workspace.Renamed",
+		"github.com/coder/guts/testdata/fieldoverrides.Workspace.Secret",
+		"github.com/coder/guts/testdata/fieldoverrides.Workspace.internal",
+		"github.com/coder/guts/testdata/fieldoverrides.WorkspaceSettings",
+		"github.com/coder/guts/testdata/notincluded.Workspace.Name",
+	}, ", "))
+}
+
+func TestInvalidCustomTypescript(t *testing.T) {
+	t.Parallel()
+
+	gen, err := guts.NewGolangParser()
+	require.NoError(t, err, "new convert")
+
+	err = gen.IncludeGenerate("./testdata/override")
+	require.NoError(t, err, "include")
+
+	err = gen.IncludeCustomTypescript(map[guts.GolangType]string{
+		"github.com/coder/guts/testdata/override.Pixels":  "`${number}px`",
+		"github.com/coder/guts/testdata/override.Created": "Date;",
+	})
+	require.ErrorContains(t, err, "github.com/coder/guts/testdata/override.Created")
+
+	// The valid mapping is not added either.
+	ts, err := gen.ToTypescript()
+	require.NoError(t, err, "to typescript")
+	output, err := ts.Serialize()
+	require.NoError(t, err, "serialize")
+	require.Contains(t, output, "type Pixels = number;")
+}
+
+func TestUnknownInt64Policy(t *testing.T) {
+	t.Parallel()
+
+	gen, err := guts.NewGolangParser()
+	require.NoError(t, err, "new convert")
+
+	err = gen.Int64As("float")
+	require.ErrorContains(t, err, `unknown int64 policy "float"`)
+
+	err = gen.Int64TypesAs(map[guts.GolangType]guts.Int64Policy{
+		"github.com/coder/guts/testdata/int64policy.ID": "float",
+	})
+	require.ErrorContains(t, err, `unknown int64 policy "float"`)
+}
+
+func TestCodecs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Int64Number", func(t *testing.T) {
+		t.Parallel()
+
+		gen, err := guts.NewGolangParser()
+		require.NoError(t, err, "new convert")
+
+		err = gen.IncludeGenerate("./testdata/codecs")
+		require.NoError(t, err, "include")
+		gen.Codecs(guts.CodecOptions{Types: config.RichTypes(), BigInt: true})
+
+		// Numbers lose their precision before they can be decoded.
+		_, err = gen.ToTypescript()
+		require.ErrorContains(t, err, `64 bit integers must use the "string" int64 policy to be decoded as a bigint: int64`)
+	})
+
+	t.Run("Generic", func(t *testing.T) {
+		t.Parallel()
+
+		gen, err := guts.NewGolangParser()
+		require.NoError(t, err, "new convert")
+
+		err = gen.IncludeGenerate("./testdata/codecs/generic")
+		require.NoError(t, err, "include")
+		gen.Codecs(guts.CodecOptions{Types: config.RichTypes()})
+
+		// Rich types from the type arguments, or the generic type itself, are
+		// not converted. Generic types without rich types are fine.
+		_, err = gen.ToTypescript()
+		require.ErrorContains(t, err, "generic types with rich types are not converted: "+strings.Join([]string{
+			"github.com/coder/guts/testdata/codecs/generic.Page[time.Time]",
+			"github.com/coder/guts/testdata/codecs/generic.Stamped[github.com/coder/guts/testdata/codecs/generic.Plain]",
+		}, ", "))
+	})
+}
+
+func TestWasmGlobalsAfterInclude(t *testing.T) {
+	t.Parallel()
+
+	gen, err := guts.NewGolangParser()
+	require.NoError(t, err, "new convert")
+
+	err = gen.IncludeGenerate("./testdata/generics")
+	require.NoError(t, err, "include")
+
+	// The included package was loaded for the host, not for wasm.
+	err = gen.WasmGlobals()
+	require.ErrorContains(t, err, "wasm globals must be enabled before any packages are included")
+}
+
+func TestCollisions(t *testing.T) {
+	t.Parallel()
+
+	gen, err := guts.NewGolangParser()
+	require.NoError(t, err, "new convert")
+
+	err = gen.IncludeGenerate("./testdata/collisions")
+	require.NoError(t, err, "include")
+	err = gen.IncludeGenerate("./testdata/collisions/other")
+	require.NoError(t, err, "include")
+	gen.GenerateVariables()
+
+	expected := []guts.Collision{
+		{Name: "DefaultUser", GoNames: []string{"github.com/coder/guts/testdata/collisions.DefaultUser", "github.com/coder/guts/testdata/collisions/other.DefaultUser"}},
+		{Name: "Rename", GoNames: []string{"github.com/coder/guts/testdata/collisions.Rename", "github.com/coder/guts/testdata/collisions/other.Rename"}},
+		{Name: "Status", GoNames: []string{"github.com/coder/guts/testdata/collisions.Status", "github.com/coder/guts/testdata/collisions/other.Status"}},
+		{Name: "User", GoNames: []string{"github.com/coder/guts/testdata/collisions.User", "github.com/coder/guts/testdata/collisions/other.User"}},
+	}
+	require.Equal(t, expected, gen.Collisions(), "up front analysis")
+
+	// Every collision is reported, not just the first one.
+	_, err = gen.ToTypescript()
+	var collisionErr *guts.CollisionError
+	require.ErrorAs(t, err, &collisionErr)
+	require.Equal(t, expected, collisionErr.Collisions, "generation")
+
+	t.Run("RenameOntoExisting", func(t *testing.T) {
+		t.Parallel()
+
+		gen, err := guts.NewGolangParser()
+		require.NoError(t, err, "new convert")
+
+		err = gen.IncludeGenerate("./testdata/collisions")
+		require.NoError(t, err, "include")
+		err = gen.RenameTypes(map[guts.GolangType]string{
+			"github.com/coder/guts/testdata/collisions.Kind": "Status",
+		})
+		require.NoError(t, err)
+
+		// The enums must not be merged into one.
+		_, err = gen.ToTypescript()
+		var collisionErr *guts.CollisionError
+		require.ErrorAs(t, err, &collisionErr)
+		require.Equal(t, []guts.Collision{
+			{Name: "Status", GoNames: []string{"github.com/coder/guts/testdata/collisions.Kind", "github.com/coder/guts/testdata/collisions.Status"}},
+		}, collisionErr.Collisions)
+	})
+}
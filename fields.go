@@ -0,0 +1,169 @@
+package guts
+
+import (
+	"go/types"
+	"reflect"
+	"sort"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/coder/guts/bindings"
+)
+
+// FieldOverride changes a single struct field. Unset values keep the
+// generated field.
+type FieldOverride struct {
+	// Type replaces the type of the field.
+	Type TypeOverride
+	// Name replaces the json name of the field.
+	Name string
+	// Optional adds, or removes, the '?' of the field.
+	Optional *bool
+	// Nullable adds, or removes, 'null' from the type of the field.
+	Nullable *bool
+	// ReadOnly adds, or removes, the 'readonly' modifier of the field.
+	ReadOnly *bool
+}
+
+// OverrideFields changes single struct fields, keyed by the fully qualified
+// golang field path. The field must be declared on the struct, not promoted
+// from an embedded struct. Paths that do not match a field are an error when
+// generating, so overrides are not silently lost when fields are renamed.
+// Eg: "github.com/your/repo/pkg.Workspace.Settings"
+func (p *GoParser) OverrideFields(overrides map[string]FieldOverride) *GoParser {
+	if p.fieldOverrides == nil {
+		p.fieldOverrides = make(map[string]FieldOverride)
+	}
+	for path, override := range overrides {
+		p.fieldOverrides[path] = override
+	}
+	return p
+}
+
+// checkFieldOverrides returns an error if any field override does not match
+// a struct field of the included packages.
+func (p *GoParser) checkFieldOverrides() error {
+	var unknown []string
+	for path := range p.fieldOverrides {
+		if !p.isStructField(path) {
+			unknown = append(unknown, path)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return xerrors.Errorf("unknown field overrides: %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// isStructField returns true if the path is a field of a struct in the
+// included packages. 'pkg.Type.Field'
+func (p *GoParser) isStructField(path string) bool {
+	typePath, fieldName, ok := cutLast(path, ".")
+	if !ok {
+		return false
+	}
+	pkgPath, typeName, ok := cutLast(typePath, ".")
+	if !ok {
+		return false
+	}
+	pkg, ok := p.Pkgs[pkgPath]
+	if !ok {
+		return false
+	}
+
+	obj, ok := pkg.Types.Scope().Lookup(typeName).(*types.TypeName)
+	if !ok {
+		return false
+	}
+	st, ok := obj.Type().Underlying().(*types.Struct)
+	if !ok {
+		return false
+	}
+	for i := 0; i < st.NumFields(); i++ {
+		field := st.Field(i)
+		if field.Name() != fieldName {
+			continue
+		}
+		// Unexported and ignored fields are never generated.
+		if !field.Exported() {
+			return false
+		}
+		jsonTag := reflect.StructTag(st.Tag(i)).Get("json")
+		return jsonTag != "-"
+	}
+	return false
+}
+
+// fieldOverride returns the override of a field of a struct.
+func (p *GoParser) fieldOverride(obj types.Object, field *types.Var) (FieldOverride, bool) {
+	if obj.Pkg() == nil {
+		return FieldOverride{}, false
+	}
+	override, ok := p.fieldOverrides[obj.Pkg().Path()+"."+obj.Name()+"."+field.Name()]
+	return override, ok
+}
+
+// apply changes the generated field.
+func (o FieldOverride) apply(field *bindings.PropertySignature) {
+	if o.Type != nil {
+		field.Type = o.Type()
+	}
+	if o.Name != "" {
+		field.Name = o.Name
+	}
+	if o.Optional != nil {
+		field.QuestionToken = *o.Optional
+	}
+	if o.Nullable != nil {
+		field.Type = setNullable(field.Type, *o.Nullable)
+	}
+	if o.ReadOnly != nil {
+		modifiers := make([]bindings.Modifier, 0, len(field.Modifiers)+1)
+		for _, modifier := range field.Modifiers {
+			if modifier != bindings.ModifierReadonly {
+				modifiers = append(modifiers, modifier)
+			}
+		}
+		if *o.ReadOnly {
+			modifiers = append(modifiers, bindings.ModifierReadonly)
+		}
+		field.Modifiers = modifiers
+	}
+}
+
+// setNullable adds, or removes, 'null' from a type.
+func setNullable(ty bindings.ExpressionType, nullable bool) bindings.ExpressionType {
+	members := []bindings.ExpressionType{ty}
+	if union, ok := ty.(*bindings.UnionType); ok {
+		members = union.Types
+	}
+
+	kept := make([]bindings.ExpressionType, 0, len(members)+1)
+	for _, member := range members {
+		if _, ok := member.(*bindings.Null); !ok {
+			kept = append(kept, member)
+		}
+	}
+	if nullable {
+		kept = append(kept, &bindings.Null{})
+	}
+	switch len(kept) {
+	case 0:
+		// A type of only 'null' is left alone.
+		return ty
+	case 1:
+		return kept[0]
+	default:
+		return bindings.Union(kept...)
+	}
+}
+
+// cutLast slices s around the last instance of sep.
+func cutLast(s, sep string) (before, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
@@ -0,0 +1,283 @@
+package guts
+
+import (
+	"fmt"
+	"go/types"
+
+	"golang.org/x/xerrors"
+
+	"github.com/coder/guts/bindings"
+)
+
+// ErrorReturns determines how a returned golang 'error' is represented in a
+// typescript function type.
+type ErrorReturns int
+
+const (
+	// ErrorReturnsDrop removes the error from the return type.
+	ErrorReturnsDrop ErrorReturns = iota
+	// ErrorReturnsThrow removes the error from the return type, and documents
+	// that the function throws. This matches bridges that convert a non-nil
+	// error into an exception, like goja.
+	ErrorReturnsThrow
+)
+
+// FunctionOptions configures how golang functions are generated as
+// typescript function types.
+type FunctionOptions struct {
+	// Errors is how a trailing 'error' return is handled.
+	Errors ErrorReturns
+	// Async wraps the return type in a 'Promise'. Use this if the functions are
+	// called asynchronously, such as from a worker or over WASM.
+	Async bool
+}
+
+// FunctionTypes generates func fields and named func types as typescript
+// function types. By default, functions are not generated, as they cannot be
+// json marshaled.
+//
+//	func(name string, values ...int) (Foo, error) --> (name: string, ...values: number[]) => Foo
+//
+// Unnamed parameters are named by their position. Multiple return values are
+// returned as a tuple.
+func (p *GoParser) FunctionTypes(opts FunctionOptions) *GoParser {
+	p.functionTypes = &opts
+	return p
+}
+
+// InterfaceMethods generates golang interfaces with methods as typescript
+// interfaces with method signatures. Embedded interfaces are extended. This is
+// useful when golang objects are exposed to javascript, such as with goja or
+// WASM. The method signatures use the options from 'FunctionTypes'.
+//
+//	type Store interface { Get(key string) (Value, error) } --> interface Store { Get(key: string): Value }
+func (p *GoParser) InterfaceMethods() *GoParser {
+	p.interfaceMethods = true
+	return p
+}
+
+func (p *GoParser) functionOptions() FunctionOptions {
+	if p.functionTypes == nil {
+		return FunctionOptions{}
+	}
+	return *p.functionTypes
+}
+
+// functionType converts a golang signature into a typescript function type.
+func (ts *Typescript) functionType(sig *types.Signature, opts FunctionOptions) (parsedType, error) {
+	fn := &bindings.FunctionType{
+		Parameters: []*bindings.Parameter{},
+	}
+	var typeParameters []*bindings.TypeParameter
+	var comments []string
+	var tags []string
+
+	params := sig.Params()
+	for i := 0; i < params.Len(); i++ {
+		param := params.At(i)
+		name := param.Name()
+		if name == "" || name == "_" {
+			name = fmt.Sprintf("arg%d", i)
+		}
+		if reservedParameterNames[name] {
+			// Golang identifiers like 'new' are not valid javascript
+			// parameter names.
+			name += "_"
+		}
+
+		rest := sig.Variadic() && i == params.Len()-1
+		paramType, err := ts.typescriptType(param.Type())
+		if err != nil {
+			return parsedType{}, xerrors.Errorf("parameter %q: %w", name, err)
+		}
+		if rest {
+			// A variadic parameter is a slice in golang, and a rest parameter
+			// is an array in typescript. A '[]byte' slice would have been
+			// converted to a string, so build the array directly.
+			slice, ok := param.Type().(*types.Slice)
+			if !ok {
+				return parsedType{}, xerrors.Errorf("variadic parameter %q is not a slice", name)
+			}
+			elem, err := ts.typescriptType(slice.Elem())
+			if err != nil {
+				return parsedType{}, xerrors.Errorf("parameter %q: %w", name, err)
+			}
+			paramType = parsedType{
+				Value:          bindings.Array(elem.Value),
+				TypeParameters: elem.TypeParameters,
+				RaisedComments: elem.RaisedComments,
+				RaisedTags:     elem.RaisedTags,
+			}
+		}
+
+		fn.Parameters = append(fn.Parameters, &bindings.Parameter{
+			Name: name,
+			Rest: rest,
+			Type: paramType.Value,
+		})
+		typeParameters = append(typeParameters, paramType.TypeParameters...)
+		comments = append(comments, paramType.RaisedComments...)
+		tags = append(tags, paramType.RaisedTags...)
+	}
+
+	var returns []bindings.ExpressionType
+	results := sig.Results()
+	for i := 0; i < results.Len(); i++ {
+		result := results.At(i)
+		if i == results.Len()-1 && isError(result.Type()) {
+			if opts.Errors == ErrorReturnsThrow {
+				tags = append(tags, "@throws Error if the golang function returns an error")
+			}
+			continue
+		}
+
+		resultType, err := ts.typescriptType(result.Type())
+		if err != nil {
+			return parsedType{}, xerrors.Errorf("result %d: %w", i, err)
+		}
+		returns = append(returns, resultType.Value)
+		typeParameters = append(typeParameters, resultType.TypeParameters...)
+		comments = append(comments, resultType.RaisedComments...)
+		tags = append(tags, resultType.RaisedTags...)
+	}
+
+	switch len(returns) {
+	case 0:
+		fn.Type = ptr(bindings.KeywordVoid)
+	case 1:
+		fn.Type = returns[0]
+	default:
+		fn.Type = bindings.Tuple(returns...)
+	}
+
+	if opts.Async {
+		fn.Type = bindings.Reference(builtInPromise, fn.Type)
+	}
+
+	simple, err := bindings.Simplify(typeParameters)
+	if err != nil {
+		return parsedType{}, xerrors.Errorf("simplify generics: %w", err)
+	}
+	return parsedType{
+		Value:          fn,
+		TypeParameters: simple,
+		RaisedComments: comments,
+		RaisedTags:     tags,
+	}, nil
+}
+
+// buildMethodInterface converts a golang interface with methods into a
+// typescript interface. Embedded interfaces are extended if they are
+// generated, otherwise their methods are included directly.
+func (ts *Typescript) buildMethodInterface(obj types.Object, intf *types.Interface) (*bindings.Interface, error) {
+	tsi := &bindings.Interface{
+		Name:       ts.parsed.Identifier(obj),
+		Modifiers:  []bindings.Modifier{},
+		Fields:     []*bindings.PropertySignature{},
+		Methods:    []*bindings.MethodSignature{},
+		Parameters: []*bindings.TypeParameter{},  // Generics
+		Heritage:   []*bindings.HeritageClause{}, // Extends
+		Source:     ts.location(obj),
+	}
+
+	if named, ok := obj.Type().(*types.Named); ok {
+		typeParameters, err := ts.typeParametersParameters(named)
+		if err != nil {
+			return tsi, xerrors.Errorf("type parameters: %w", err)
+		}
+		tsi.Parameters = typeParameters
+	}
+
+	methods := []*types.Func{}
+	for i := 0; i < intf.NumExplicitMethods(); i++ {
+		methods = append(methods, intf.ExplicitMethod(i))
+	}
+
+	var extends []bindings.ExpressionType
+	for i := 0; i < intf.NumEmbeddeds(); i++ {
+		embedded := intf.EmbeddedType(i)
+		heritage, err := ts.typescriptType(embedded)
+		if err != nil {
+			return tsi, xerrors.Errorf("heritage type: %w", err)
+		}
+
+		if _, ok := heritage.Value.(*bindings.ReferenceType); ok {
+			extends = append(extends, heritage.Value)
+			tsi.Parameters = append(tsi.Parameters, heritage.TypeParameters...)
+			continue
+		}
+
+		// The embedded interface is not generated, like 'error' or an
+		// external package. Include the methods directly.
+		embeddedIntf, ok := embedded.Underlying().(*types.Interface)
+		if !ok {
+			return tsi, xerrors.Errorf("embedded type %q is not an interface", embedded.String())
+		}
+		for j := 0; j < embeddedIntf.NumMethods(); j++ {
+			methods = append(methods, embeddedIntf.Method(j))
+		}
+	}
+	if len(extends) > 0 {
+		tsi.Heritage = append(tsi.Heritage, bindings.HeritageClauseExtends(extends...))
+	}
+
+	opts := ts.parsed.functionOptions()
+	for _, method := range methods {
+		if !method.Exported() {
+			continue
+		}
+
+		sig, ok := method.Type().(*types.Signature)
+		if !ok {
+			return tsi, xerrors.Errorf("method %q is not a function", method.Name())
+		}
+		parsed, err := ts.functionType(sig, opts)
+		if err != nil {
+			return tsi, xerrors.Errorf("method %q: %w", method.Name(), err)
+		}
+		fn := parsed.Value.(*bindings.FunctionType)
+
+		tsMethod := &bindings.MethodSignature{
+			Name:       method.Name(),
+			Parameters: fn.Parameters,
+			Type:       fn.Type,
+		}
+		for _, c := range parsed.RaisedComments {
+			tsMethod.LeadingComment(c)
+		}
+		if ts.preserveComments {
+			cmts := ts.parsed.CommentForObject(method)
+			tsMethod.AppendComments(cmts)
+		}
+		for _, tag := range parsed.RaisedTags {
+			tsMethod.JSDocTag(tag)
+		}
+
+		tsi.Parameters = append(tsi.Parameters, parsed.TypeParameters...)
+		tsi.Methods = append(tsi.Methods, tsMethod)
+	}
+
+	simple, err := bindings.Simplify(tsi.Parameters)
+	if err != nil {
+		return tsi, xerrors.Errorf("simplify generics: %w", err)
+	}
+	tsi.Parameters = simple
+	return tsi, nil
+}
+
+func isError(ty types.Type) bool {
+	return types.Identical(ty, types.Universe.Lookup("error").Type())
+}
+
+// reservedParameterNames are javascript reserved words that are valid golang
+// identifiers.
+var reservedParameterNames = map[string]bool{
+	"await": true, "catch": true, "class": true, "debugger": true,
+	"delete": true, "do": true, "enum": true, "export": true,
+	"extends": true, "false": true, "finally": true, "function": true,
+	"implements": true, "in": true, "instanceof": true, "let": true,
+	"new": true, "null": true, "private": true, "protected": true,
+	"public": true, "static": true, "super": true, "this": true,
+	"throw": true, "true": true, "try": true, "typeof": true,
+	"void": true, "while": true, "with": true, "yield": true,
+}
@@ -0,0 +1,187 @@
+package guts
+
+import (
+	"fmt"
+	"go/constant"
+	"go/types"
+	"log/slog"
+	"math/big"
+
+	"golang.org/x/xerrors"
+
+	"github.com/coder/guts/bindings"
+)
+
+// Int64Policy is the typescript type used for 64 bit integers. Javascript
+// numbers are only precise up to 2^53, so larger values lose precision
+// when parsed as a 'number'.
+type Int64Policy string
+
+const (
+	// Int64Number uses 'number'. Values above 2^53 lose precision. This is the
+	// default, and matches what 'JSON.parse' does.
+	Int64Number Int64Policy = "number"
+	// Int64String uses 'string'. Pair this with the ',string' json tag option,
+	// or a custom marshaler, so the values are sent as strings.
+	Int64String Int64Policy = "string"
+	// Int64BigInt uses 'bigint'. The json must be parsed with a reviver that
+	// creates bigints. Enum members cannot be bigints, so integer enums are a
+	// union of their values.
+	Int64BigInt Int64Policy = "bigint"
+)
+
+// maxSafeInteger is the largest integer a javascript number can represent
+// exactly. 'Number.MAX_SAFE_INTEGER'
+const maxSafeInteger = 1<<53 - 1
+
+// Int64As sets the policy for all 64 bit integers. This includes 'int' and
+// 'uint', which are 64 bits on most platforms.
+func (p *GoParser) Int64As(policy Int64Policy) error {
+	if !policy.valid() {
+		return xerrors.Errorf("unknown int64 policy %q", policy)
+	}
+	p.int64Policy = policy
+	return nil
+}
+
+// Int64TypesAs sets the policy for specific named integer types. These take
+// precedence over the global policy.
+// Eg: "github.com/your/repo/pkg.ID": guts.Int64String
+func (p *GoParser) Int64TypesAs(policies map[GolangType]Int64Policy) error {
+	for k, v := range policies {
+		if !v.valid() {
+			return xerrors.Errorf("type %q: unknown int64 policy %q", k, v)
+		}
+		p.int64Policies[k] = v
+	}
+	return nil
+}
+
+func (policy Int64Policy) valid() bool {
+	switch policy {
+	case Int64Number, Int64String, Int64BigInt:
+		return true
+	}
+	return false
+}
+
+// is64BitInteger returns true if the type is an integer type that can hold
+// values larger than a javascript number can represent.
+func is64BitInteger(ty types.Type) bool {
+	basic, ok := ty.Underlying().(*types.Basic)
+	if !ok {
+		return false
+	}
+	switch basic.Kind() {
+	case types.Int, types.Int64, types.Uint, types.Uint64, types.Uintptr:
+		return true
+	}
+	return false
+}
+
+// int64PolicyFor returns the policy of a 64 bit integer type. The second
+// return is false if the type is not a 64 bit integer.
+func (p *GoParser) int64PolicyFor(ty types.Type) (Int64Policy, bool) {
+	if !is64BitInteger(ty) {
+		return "", false
+	}
+	if policy, ok := p.int64Policies[ty.String()]; ok {
+		return policy, true
+	}
+	if p.int64Policy == "" {
+		return Int64Number, true
+	}
+	return p.int64Policy, true
+}
+
+// int64PolicyFor returns the policy of a 64 bit integer type, in the field
+// that is being generated.
+func (ts *Typescript) int64PolicyFor(ty types.Type) (Int64Policy, bool) {
+	policy, ok := ts.parsed.int64PolicyFor(ty)
+	if ok && ts.fieldInt64Policy != "" {
+		return ts.fieldInt64Policy, true
+	}
+	return policy, ok
+}
+
+func int64Keyword(policy Int64Policy) bindings.LiteralKeyword {
+	switch policy {
+	case Int64String:
+		return bindings.KeywordString
+	case Int64BigInt:
+		return bindings.KeywordBigInt
+	default:
+		return bindings.KeywordNumber
+	}
+}
+
+// integerValue serializes an integer constant of the given type without
+// losing precision. Values outside the precise range of a javascript number
+// produce a warning when they are serialized as a number.
+func (ts *Typescript) integerValue(name string, ty types.Type, val constant.Value) (bindings.ExpressionType, error) {
+	policy, ok := ts.parsed.int64PolicyFor(ty)
+	if !ok {
+		policy = Int64Number
+	}
+
+	switch policy {
+	case Int64String:
+		return &bindings.LiteralType{Value: val.ExactString()}, nil
+	case Int64BigInt:
+		i, ok := new(big.Int).SetString(val.ExactString(), 10)
+		if !ok {
+			return nil, xerrors.Errorf("const %q is not an integer", name)
+		}
+		return bindings.BigInt(i), nil
+	}
+
+	var value any
+	if v, exact := constant.Int64Val(val); exact {
+		value = v
+		if v > maxSafeInteger || v < -maxSafeInteger {
+			warnUnsafeInteger(name, val)
+		}
+	} else if v, exact := constant.Uint64Val(val); exact {
+		value = v
+		warnUnsafeInteger(name, val)
+	} else {
+		return nil, xerrors.Errorf("const %q overflows 64 bits", name)
+	}
+	return &bindings.LiteralType{Value: value}, nil
+}
+
+func warnUnsafeInteger(name string, val constant.Value) {
+	slog.Warn("integer constant is outside the precise range of a javascript number. "+
+		"Use 'Int64As' or 'Int64TypesAs' with 'bigint' or 'string' to keep the precision.",
+		slog.String("const", name), slog.String("value", val.ExactString()))
+}
+
+// stringOptionType returns the type of a field with the ',string' json option.
+// Only numbers and booleans, or pointers to them, are encoded as strings.
+func stringOptionType(ty types.Type) (bindings.ExpressionType, bool) {
+	nullable := false
+	if ptrType, ok := ty.(*types.Pointer); ok {
+		ty = ptrType.Elem()
+		nullable = true
+	}
+	basic, ok := ty.Underlying().(*types.Basic)
+	if !ok || basic.Info()&(types.IsNumeric|types.IsBoolean) == 0 {
+		return nil, false
+	}
+	if nullable {
+		return bindings.Union(ptr(bindings.KeywordString), &bindings.Null{}), true
+	}
+	return ptr(bindings.KeywordString), true
+}
+
+// quotedValue converts a value to the string encoding used by the ',string'
+// json option.
+func quotedValue(value bindings.ExpressionType) bindings.ExpressionType {
+	switch v := value.(type) {
+	case *bindings.LiteralType:
+		return &bindings.LiteralType{Value: fmt.Sprint(v.Value)}
+	case *bindings.BigIntLiteral:
+		return &bindings.LiteralType{Value: v.Value}
+	}
+	return value
+}
@@ -1,6 +1,7 @@
 package guts
 
 import (
+	"go/token"
 	"go/types"
 	"path"
 	"path/filepath"
@@ -9,11 +10,15 @@ import (
 )
 
 func (ts *Typescript) location(obj types.Object) bindings.Source {
-	file := ts.parsed.fileSet.File(obj.Pos())
-	position := file.Position(obj.Pos())
+	return ts.locationAt(obj.Pkg(), obj.Pos())
+}
+
+func (ts *Typescript) locationAt(pkg *types.Package, pos token.Pos) bindings.Source {
+	file := ts.parsed.fileSet.File(pos)
+	position := file.Position(pos)
 	return bindings.Source{
 		// Do not use filepath, as that changes behavior based on OS
-		File:     path.Join(obj.Pkg().Name(), filepath.Base(file.Name())),
+		File:     path.Join(pkg.Name(), filepath.Base(file.Name())),
 		Position: position,
 	}
 }
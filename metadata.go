@@ -0,0 +1,42 @@
+package guts
+
+import (
+	"go/types"
+	"reflect"
+
+	"github.com/coder/guts/bindings"
+)
+
+// GoField is the golang struct field a typescript field is generated from.
+type GoField struct {
+	Var *types.Var
+	// Tag is the raw struct tag of the field.
+	Tag reflect.StructTag
+}
+
+// GoObject returns the golang object the declaration at the key is generated
+// from. Declarations added by mutations, or generated without a golang
+// object, return false.
+func (ts *Typescript) GoObject(key string) (types.Object, bool) {
+	if _, ok := ts.typescriptNodes[key]; !ok {
+		return nil, false
+	}
+	obj, ok := ts.objects[key]
+	return obj, ok
+}
+
+// GoField returns the golang struct field an interface field is generated
+// from. The field is matched by pointer, so clones of a field return false.
+//
+//	ts.ForEach(func(key string, node bindings.Node) {
+//		intf, ok := node.(*bindings.Interface)
+//		...
+//		for _, field := range intf.Fields {
+//			goField, ok := ts.GoField(field)
+//			if ok && goField.Tag.Get("db") != "" { ... }
+//		}
+//	})
+func (ts *Typescript) GoField(field *bindings.PropertySignature) (GoField, bool) {
+	f, ok := ts.fields[field]
+	return f, ok
+}
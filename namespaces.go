@@ -0,0 +1,145 @@
+package guts
+
+import (
+	"go/types"
+	"maps"
+	"slices"
+
+	"github.com/coder/guts/bindings"
+)
+
+// PackageNamespaces places the generated types of each golang package into a
+// typescript namespace named after the package. References to types in other
+// packages are qualified with the namespace.
+// This is an alternative to prefixing the types of each package.
+//
+//	export namespace pkg {
+//	    export interface User { ... }
+//	}
+//
+// Use 'config.ExportTypes' so the types can be referenced from other
+// namespaces.
+//
+// Packages with the same name use more of the package path, like
+// 'PrefixCollisions'. Eg: "github.com/a/v1/user" -> "V1User"
+func (p *GoParser) PackageNamespaces() *GoParser {
+	p.namespaces = true
+	return p
+}
+
+// namespaceName returns the namespace of the package. Packages that are not
+// included use the package name.
+func (p *GoParser) namespaceName(pkg *types.Package) string {
+	if name, ok := p.namespaceNames[pkg.Path()]; ok {
+		return name
+	}
+	if pkg.Name() == globalNamespace {
+		prefix, _ := packagePrefix(pkg.Path(), 1)
+		return prefix
+	}
+	return pkg.Name()
+}
+
+// buildNamespaceNames returns the namespace of each included package. The
+// package name is used if it is unique. Otherwise, the namespace is built
+// from the package path, using as many path elements as needed to make the
+// namespaces unique. The 'global' namespace is reserved for declarations in
+// the global scope.
+func (p *GoParser) buildNamespaceNames() map[string]string {
+	byName := make(map[string][]string)
+	for _, pkgPath := range slices.Sorted(maps.Keys(p.Pkgs)) {
+		name := p.Pkgs[pkgPath].Types.Name()
+		byName[name] = append(byName[name], pkgPath)
+	}
+
+	names := make(map[string]string)
+	for name, pkgPaths := range byName {
+		if len(pkgPaths) == 1 && name != globalNamespace {
+			names[pkgPaths[0]] = name
+			continue
+		}
+		for depth := 1; ; depth++ {
+			used := make(map[string]struct{})
+			unique, exhausted := true, true
+			for _, pkgPath := range pkgPaths {
+				prefix, more := packagePrefix(pkgPath, depth)
+				exhausted = exhausted && !more
+				if _, ok := used[prefix]; ok {
+					unique = false
+				}
+				used[prefix] = struct{}{}
+				names[pkgPath] = prefix
+			}
+			if unique || exhausted {
+				break
+			}
+		}
+	}
+	return names
+}
+
+// groupNamespaces moves all nodes declared in a namespace into a namespace
+// block. The namespace block is placed where the first node of the namespace
+// is, and the nodes keep their order within the namespace.
+func groupNamespaces(order []bindings.Node) []bindings.Node {
+	grouped := make([]bindings.Node, 0, len(order))
+	namespaces := make(map[string]*bindings.Namespace)
+	for _, node := range order {
+		ident, ok := declarationIdentifier(node)
+		decl, isDecl := node.(bindings.DeclarationType)
+		if !ok || !isDecl || ident.Namespace == "" {
+			grouped = append(grouped, node)
+			continue
+		}
+
+		ns, ok := namespaces[ident.Namespace]
+		if !ok {
+			ns = &bindings.Namespace{
+				Name:      ident.Namespace,
+				Modifiers: []bindings.Modifier{},
+			}
+			if ident.Namespace == globalNamespace {
+				ns.Global = true
+				ns.Modifiers = append(ns.Modifiers, bindings.ModifierDeclare)
+			}
+			namespaces[ident.Namespace] = ns
+			grouped = append(grouped, ns)
+		}
+		ns.Statements = append(ns.Statements, decl)
+		if ns.Global {
+			continue
+		}
+
+		// The namespace is exported if any of the declarations are.
+		if hasModifier(node, bindings.ModifierExport) && !slices.Contains(ns.Modifiers, bindings.ModifierExport) {
+			ns.Modifiers = append(ns.Modifiers, bindings.ModifierExport)
+		}
+	}
+	return grouped
+}
+
+// hasGlobalNamespace returns true if any of the nodes is a 'declare global'
+// block.
+func hasGlobalNamespace(order []bindings.Node) bool {
+	return slices.ContainsFunc(order, func(node bindings.Node) bool {
+		ns, ok := node.(*bindings.Namespace)
+		return ok && ns.Global
+	})
+}
+
+func hasModifier(node bindings.Node, modifier bindings.Modifier) bool {
+	var modifiers []bindings.Modifier
+	switch node := node.(type) {
+	case *bindings.Interface:
+		modifiers = node.Modifiers
+	case *bindings.Alias:
+		modifiers = node.Modifiers
+	case *bindings.Enum:
+		modifiers = node.Modifiers
+	case *bindings.VariableStatement:
+		modifiers = node.Modifiers
+	case *bindings.FunctionDeclaration:
+		modifiers = node.Modifiers
+	}
+	return slices.Contains(modifiers, modifier)
+}
@@ -2,6 +2,8 @@ package guts
 
 import (
 	"fmt"
+	"go/token"
+	"sort"
 
 	"github.com/coder/guts/bindings"
 )
@@ -12,13 +14,25 @@ type typescriptNode struct {
 	// it can be serialized to typescript. It exists for ensuring consistent ordering
 	// of execution, regardless of the parsing order.
 	// These mutations can be anything.
-	mutations []func(v bindings.Node) (bindings.Node, error)
+	mutations []nodeMutation
+}
+
+type nodeMutation struct {
+	// pos is the position of the golang object that caused the mutation.
+	// Mutations are applied in golang declaration order, not the order they
+	// were added in.
+	pos   token.Pos
+	apply func(v bindings.Node) (bindings.Node, error)
 }
 
 func (t typescriptNode) applyMutations() (typescriptNode, error) {
+	sort.SliceStable(t.mutations, func(i, j int) bool {
+		return t.mutations[i].pos < t.mutations[j].pos
+	})
+
 	for i, m := range t.mutations {
 		var err error
-		t.Node, err = m(t.Node)
+		t.Node, err = m.apply(t.Node)
 		if err != nil {
 			return t, fmt.Errorf("apply mutation %d: %w", i, err)
 		}
@@ -27,13 +41,21 @@ func (t typescriptNode) applyMutations() (typescriptNode, error) {
 	return t, nil
 }
 
-func (t *typescriptNode) AddEnum(member *bindings.EnumMember) {
-	t.mutations = append(t.mutations, func(v bindings.Node) (bindings.Node, error) {
+// AddEnum adds a member to the enum. The position is the position of the
+// golang constant, and is used to keep the members in declaration order.
+func (t *typescriptNode) AddEnum(member *bindings.EnumMember, pos token.Pos) {
+	t.mutations = append(t.mutations, nodeMutation{pos: pos, apply: func(v bindings.Node) (bindings.Node, error) {
 		if v == nil {
 			// Just delete the enum if the reference type cannot be found.
 			return nil, nil
 		}
 
+		if value, ok := member.Value.(*bindings.BigIntLiteral); ok {
+			// Enum members cannot be bigints, so the enum is a union of the
+			// values instead.
+			return addBigIntMember(v, value)
+		}
+
 		alias, ok := v.(*bindings.Alias)
 		if ok {
 			// Switch to an enum
@@ -54,5 +76,20 @@ func (t *typescriptNode) AddEnum(member *bindings.EnumMember) {
 
 		enum.Members = append(enum.Members, member)
 		return enum, nil
-	})
+	}})
+}
+
+// addBigIntMember adds a bigint value to the union of a bigint enum. The first
+// value replaces the 'bigint' type of the alias.
+func addBigIntMember(v bindings.Node, value *bindings.BigIntLiteral) (bindings.Node, error) {
+	alias, ok := v.(*bindings.Alias)
+	if !ok {
+		return v, fmt.Errorf("expected alias, got %T", v)
+	}
+	if union, ok := alias.Type.(*bindings.UnionType); ok {
+		union.Types = append(union.Types, value)
+		return alias, nil
+	}
+	alias.Type = bindings.Union(value)
+	return alias, nil
 }
@@ -0,0 +1,584 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/coder/guts"
+	"github.com/coder/guts/bindings"
+	"github.com/coder/guts/bindings/walk"
+)
+
+// componentRef is the prefix of all schema references.
+const componentRef = "#/components/schemas/"
+
+// invalidNameChars are not allowed in component names.
+var invalidNameChars = regexp.MustCompile(`[^a-zA-Z0-9._-]`)
+
+type converter struct {
+	nodes   map[string]bindings.Node
+	schemas map[string]*Schema
+	// queued are the names of all generic instantiations that are pending or
+	// already converted.
+	queued  map[string]bool
+	pending []instantiation
+}
+
+// instantiation is a generic type with concrete type arguments.
+type instantiation struct {
+	name string
+	key  string
+	args []bindings.ExpressionType
+}
+
+func newConverter(ts *guts.Typescript) *converter {
+	nodes := make(map[string]bindings.Node)
+	ts.ForEach(func(key string, node bindings.Node) {
+		nodes[key] = node
+	})
+	return &converter{
+		nodes:   nodes,
+		schemas: make(map[string]*Schema),
+		queued:  make(map[string]bool),
+	}
+}
+
+func (c *converter) convert() (map[string]*Schema, error) {
+	keys := make([]string, 0, len(c.nodes))
+	for k := range c.nodes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		node := c.nodes[key]
+		if len(typeParameters(node)) > 0 {
+			// Generics are expanded when they are referenced.
+			continue
+		}
+
+		schema, err := c.declaration(node)
+		if err != nil {
+			return nil, xerrors.Errorf("schema %q: %w", key, err)
+		}
+		if schema != nil {
+			c.schemas[key] = schema
+		}
+	}
+
+	// Instantiations can reference more instantiations.
+	for len(c.pending) > 0 {
+		inst := c.pending[0]
+		c.pending = c.pending[1:]
+
+		schema, err := c.declaration(instantiate(c.nodes[inst.key], inst.args))
+		if err != nil {
+			return nil, xerrors.Errorf("schema %q: %w", inst.name, err)
+		}
+		if schema != nil {
+			c.schemas[inst.name] = schema
+		}
+	}
+	return c.schemas, nil
+}
+
+// declaration converts a top level node. A nil schema is returned for nodes
+// that are not json data.
+func (c *converter) declaration(node bindings.Node) (*Schema, error) {
+	switch node := node.(type) {
+	case *bindings.Interface:
+		if len(node.Methods) > 0 {
+			// Interfaces with methods are behavior, not data.
+			return nil, nil
+		}
+
+		obj, err := c.object(node.Fields)
+		if err != nil {
+			return nil, err
+		}
+		if len(node.Heritage) == 0 {
+			describe(obj, node.Comments())
+			return obj, nil
+		}
+
+		// Inheritance is all the parents, and the fields.
+		schema := &Schema{}
+		for _, heritage := range node.Heritage {
+			for _, arg := range heritage.Args {
+				parent, err := c.expression(arg)
+				if err != nil {
+					return nil, xerrors.Errorf("heritage: %w", err)
+				}
+				if parent != nil {
+					schema.AllOf = append(schema.AllOf, parent)
+				}
+			}
+		}
+		schema.AllOf = append(schema.AllOf, obj)
+		describe(schema, node.Comments())
+		return schema, nil
+	case *bindings.Alias:
+		schema, err := c.expression(node.Type)
+		if err != nil {
+			return nil, err
+		}
+		if schema != nil {
+			describe(schema, node.Comments())
+		}
+		return schema, nil
+	case *bindings.Enum:
+		values := make([]any, 0, len(node.Members))
+		for _, member := range node.Members {
+			value, ok := literalValue(member.Value)
+			if !ok {
+				return nil, xerrors.Errorf("enum member %q: unsupported value %T", member.Name, member.Value)
+			}
+			values = append(values, value)
+		}
+		return enumSchema(values, node.Comments()), nil
+	case *bindings.VariableStatement:
+		// Values are not types.
+		return nil, nil
+	case *bindings.FunctionDeclaration:
+		// Functions are not types.
+		return nil, nil
+	default:
+		return nil, xerrors.Errorf("unsupported node %T", node)
+	}
+}
+
+func enumSchema(values []any, comments []bindings.SyntheticComment) *Schema {
+	schema := &Schema{
+		Type: jsonTypes(values),
+		Enum: values,
+	}
+	describe(schema, comments)
+	return schema
+}
+
+func (c *converter) object(fields []*bindings.PropertySignature) (*Schema, error) {
+	schema := &Schema{
+		Type: Types{"object"},
+	}
+	for _, field := range fields {
+		property, err := c.expression(field.Type)
+		if err != nil {
+			return nil, xerrors.Errorf("field %q: %w", field.Name, err)
+		}
+		if property == nil {
+			continue
+		}
+		describe(property, field.Comments())
+		constrain(property, field.Constraints)
+
+		if schema.Properties == nil {
+			schema.Properties = make(map[string]*Schema)
+		}
+		schema.Properties[field.Name] = property
+		if !field.QuestionToken {
+			schema.Required = append(schema.Required, field.Name)
+		}
+	}
+	return schema, nil
+}
+
+// expression converts a type. A nil schema is returned for types that cannot
+// be json data, like functions.
+func (c *converter) expression(ty bindings.ExpressionType) (*Schema, error) {
+	switch ty := ty.(type) {
+	case *bindings.LiteralKeyword:
+		switch *ty {
+		case bindings.KeywordString:
+			return &Schema{Type: Types{"string"}}, nil
+		case bindings.KeywordNumber:
+			return &Schema{Type: Types{"number"}}, nil
+		case bindings.KeywordBoolean:
+			return &Schema{Type: Types{"boolean"}}, nil
+		case bindings.KeywordBigInt:
+			return &Schema{Type: Types{"integer"}, Format: "int64"}, nil
+		case bindings.KeywordObject:
+			return &Schema{Type: Types{"object"}}, nil
+		case bindings.KeywordAny, bindings.KeywordUnknown:
+			return &Schema{}, nil
+		default:
+			return nil, xerrors.Errorf("unsupported keyword %q", *ty)
+		}
+	case *bindings.Null:
+		return &Schema{Type: Types{"null"}}, nil
+	case *bindings.LiteralType, *bindings.BigIntLiteral, *bindings.PrefixUnaryExpression:
+		value, ok := literalValue(ty)
+		if !ok {
+			return nil, xerrors.Errorf("unsupported literal %T", ty)
+		}
+		return &Schema{Const: value}, nil
+	case *bindings.ReferenceType:
+		return c.reference(ty)
+	case *bindings.ArrayType:
+		items, err := c.expression(ty.Node)
+		if err != nil {
+			return nil, xerrors.Errorf("array: %w", err)
+		}
+		return &Schema{Type: Types{"array"}, Items: items}, nil
+	case *bindings.TupleType:
+		if ty.Elements == nil {
+			items, err := c.expression(ty.Node)
+			if err != nil {
+				return nil, xerrors.Errorf("tuple: %w", err)
+			}
+			return &Schema{
+				Type:     Types{"array"},
+				Items:    items,
+				MinItems: &ty.Length,
+				MaxItems: &ty.Length,
+			}, nil
+		}
+
+		length := len(ty.Elements)
+		schema := &Schema{
+			Type:     Types{"array"},
+			MinItems: &length,
+			MaxItems: &length,
+		}
+		for _, elem := range ty.Elements {
+			item, err := c.expression(elem)
+			if err != nil {
+				return nil, xerrors.Errorf("tuple: %w", err)
+			}
+			schema.PrefixItems = append(schema.PrefixItems, item)
+		}
+		return schema, nil
+	case *bindings.UnionType:
+		return c.union(ty)
+	case *bindings.TypeIntersection:
+		schema := &Schema{}
+		for _, t := range ty.Types {
+			s, err := c.expression(t)
+			if err != nil {
+				return nil, xerrors.Errorf("intersection: %w", err)
+			}
+			if s != nil {
+				schema.AllOf = append(schema.AllOf, s)
+			}
+		}
+		return schema, nil
+	case *bindings.OperatorNodeType:
+		if ty.Keyword == bindings.KeywordKeyOf {
+			return &Schema{Type: Types{"string"}}, nil
+		}
+		// 'readonly' does not change the json.
+		return c.expression(ty.Type)
+	case *bindings.TypeLiteralNode:
+		if len(ty.Methods) > 0 {
+			return nil, nil
+		}
+		return c.object(ty.Members)
+	case *bindings.TemplateLiteralType:
+		return &Schema{Type: Types{"string"}}, nil
+	case *bindings.FunctionType:
+		return nil, nil
+	default:
+		return nil, xerrors.Errorf("unsupported type %T", ty)
+	}
+}
+
+func (c *converter) union(union *bindings.UnionType) (*Schema, error) {
+	nullable := false
+	types := make([]bindings.ExpressionType, 0, len(union.Types))
+	for _, t := range union.Types {
+		if _, ok := t.(*bindings.Null); ok {
+			nullable = true
+			continue
+		}
+		types = append(types, t)
+	}
+
+	// A union of only literals is an enum.
+	values := make([]any, 0, len(types))
+	for _, t := range types {
+		value, ok := literalValue(t)
+		if !ok {
+			values = nil
+			break
+		}
+		values = append(values, value)
+	}
+	if len(values) > 0 {
+		schema := &Schema{
+			Type: jsonTypes(values),
+			Enum: values,
+		}
+		if nullable {
+			schema.Type = append(schema.Type, "null")
+			schema.Enum = append(schema.Enum, nil)
+		}
+		return schema, nil
+	}
+
+	var schemas []*Schema
+	for _, t := range types {
+		s, err := c.expression(t)
+		if err != nil {
+			return nil, xerrors.Errorf("union: %w", err)
+		}
+		if s != nil {
+			schemas = append(schemas, s)
+		}
+	}
+
+	var schema *Schema
+	switch len(schemas) {
+	case 0:
+		if !nullable {
+			return nil, nil
+		}
+		return &Schema{Type: Types{"null"}}, nil
+	case 1:
+		schema = schemas[0]
+	default:
+		schema = &Schema{AnyOf: schemas}
+	}
+
+	if nullable {
+		return nullableSchema(schema), nil
+	}
+	return schema, nil
+}
+
+func (c *converter) reference(ref *bindings.ReferenceType) (*Schema, error) {
+	if ref.Name.Package == nil {
+		// Typescript built in types.
+		switch {
+		case ref.Name.Name == "Record" && len(ref.Arguments) == 2:
+			values, err := c.expression(ref.Arguments[1])
+			if err != nil {
+				return nil, xerrors.Errorf("record: %w", err)
+			}
+			return &Schema{Type: Types{"object"}, AdditionalProperties: values}, nil
+		case ref.Name.Name == "Readonly" && len(ref.Arguments) == 1:
+			return c.expression(ref.Arguments[0])
+		}
+	}
+
+	key := ref.Name.Qualified()
+	node, ok := c.nodes[key]
+	if !ok {
+		// Not a generated type, so nothing is known about it.
+		return &Schema{}, nil
+	}
+	if len(typeParameters(node)) == 0 {
+		return &Schema{Ref: componentRef + key}, nil
+	}
+
+	name := instanceName(key, ref.Arguments)
+	if !c.queued[name] {
+		c.queued[name] = true
+		c.pending = append(c.pending, instantiation{
+			name: name,
+			key:  key,
+			args: ref.Arguments,
+		})
+	}
+	return &Schema{Ref: componentRef + name}, nil
+}
+
+// nullableSchema adds 'null' as a valid value of the schema.
+func nullableSchema(schema *Schema) *Schema {
+	switch {
+	case len(schema.AnyOf) > 0:
+		schema.AnyOf = append(schema.AnyOf, &Schema{Type: Types{"null"}})
+		return schema
+	case len(schema.Type) > 0 && schema.Ref == "" && schema.Enum == nil && schema.Const == nil && len(schema.AllOf) == 0:
+		if !slices.Contains(schema.Type, "null") {
+			schema.Type = append(schema.Type, "null")
+		}
+		return schema
+	default:
+		return &Schema{AnyOf: []*Schema{schema, {Type: Types{"null"}}}}
+	}
+}
+
+// constrain adds the validation constraints of a property to its schema.
+// Keywords only apply to values of the matching json type, so they are set
+// even if the schema is a union.
+func constrain(schema *Schema, c *bindings.Constraints) {
+	if c == nil {
+		return
+	}
+	schema.MinLength = c.MinLength
+	schema.MaxLength = c.MaxLength
+	schema.Pattern = c.Pattern
+	if c.MinItems != nil {
+		schema.MinItems = c.MinItems
+	}
+	if c.MaxItems != nil {
+		schema.MaxItems = c.MaxItems
+	}
+	schema.Minimum = c.Minimum
+	schema.Maximum = c.Maximum
+	schema.ExclusiveMinimum = c.ExclusiveMinimum
+	schema.ExclusiveMaximum = c.ExclusiveMaximum
+	if c.Format != "" && schema.Format == "" {
+		schema.Format = c.Format
+	}
+	if len(c.OneOf) > 0 && len(schema.Enum) == 0 {
+		schema.Enum = c.OneOf
+	}
+}
+
+// describe sets the description and deprecation from the golang comments.
+// Comments added by guts itself are not included.
+func describe(schema *Schema, comments []bindings.SyntheticComment) {
+	var lines []string
+	for _, cmt := range comments {
+		if cmt.DoNotFormat {
+			continue
+		}
+		if _, ok := bindings.DeprecationNotice(cmt.Text); ok {
+			schema.Deprecated = true
+			continue
+		}
+		lines = append(lines, strings.TrimSpace(cmt.Text))
+	}
+	schema.Description = strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+func typeParameters(node bindings.Node) []*bindings.TypeParameter {
+	switch node := node.(type) {
+	case *bindings.Interface:
+		return node.Parameters
+	case *bindings.Alias:
+		return node.Parameters
+	}
+	return nil
+}
+
+// instantiate replaces the type parameters of a generic node with the
+// arguments. Missing arguments use the constraint of the parameter.
+func instantiate(node bindings.Node, args []bindings.ExpressionType) bindings.Node {
+	substitutions := make(map[string]bindings.ExpressionType)
+	for i, param := range typeParameters(node) {
+		arg := param.Type
+		if i < len(args) {
+			arg = args[i]
+		}
+		if arg == nil {
+			arg = ptr(bindings.KeywordUnknown)
+		}
+		substitutions[param.Name.Ref()] = arg
+	}
+
+	cpy := bindings.Clone(node)
+	switch cpy := cpy.(type) {
+	case *bindings.Interface:
+		cpy.Parameters = nil
+	case *bindings.Alias:
+		cpy.Parameters = nil
+	}
+
+	return walk.Apply(cpy, func(cursor *walk.Cursor) bool {
+		ref, ok := cursor.Node().(*bindings.ReferenceType)
+		if !ok || len(ref.Arguments) > 0 {
+			return true
+		}
+		if arg, ok := substitutions[ref.Name.Ref()]; ok {
+			cursor.Replace(bindings.Clone(arg))
+			return false
+		}
+		return true
+	}, nil)
+}
+
+// instanceName is the component name of a generic instantiation.
+func instanceName(key string, args []bindings.ExpressionType) string {
+	parts := []string{key}
+	for _, arg := range args {
+		parts = append(parts, argumentName(arg))
+	}
+	return invalidNameChars.ReplaceAllString(strings.Join(parts, "_"), "_")
+}
+
+func argumentName(arg bindings.ExpressionType) string {
+	switch arg := arg.(type) {
+	case *bindings.ReferenceType:
+		return instanceName(arg.Name.Qualified(), arg.Arguments)
+	case *bindings.LiteralKeyword:
+		return strings.ToLower(strings.TrimSuffix(string(*arg), "Keyword"))
+	case *bindings.ArrayType:
+		return argumentName(arg.Node) + "Array"
+	case *bindings.OperatorNodeType:
+		return argumentName(arg.Type)
+	case *bindings.Null:
+		return "null"
+	case *bindings.UnionType:
+		names := make([]string, 0, len(arg.Types))
+		for _, t := range arg.Types {
+			names = append(names, argumentName(t))
+		}
+		return strings.Join(names, "Or")
+	case *bindings.LiteralType:
+		return fmt.Sprint(arg.Value)
+	default:
+		return "unknown"
+	}
+}
+
+// literalValue returns the json value of a literal type.
+func literalValue(ty bindings.ExpressionType) (any, bool) {
+	switch ty := ty.(type) {
+	case *bindings.LiteralType:
+		return ty.Value, ty.Value != nil
+	case *bindings.BigIntLiteral:
+		return json.Number(ty.Value), true
+	case *bindings.PrefixUnaryExpression:
+		if ty.Operator != bindings.PrefixMinus {
+			return nil, false
+		}
+		switch operand := ty.Operand.(type) {
+		case *bindings.LiteralType:
+			switch v := operand.Value.(type) {
+			case int64:
+				return -v, true
+			case float64:
+				return -v, true
+			}
+		case *bindings.BigIntLiteral:
+			return json.Number("-" + operand.Value), true
+		}
+	}
+	return nil, false
+}
+
+// jsonTypes returns the unique json types of the values, in order.
+func jsonTypes(values []any) Types {
+	var types Types
+	for _, v := range values {
+		var t string
+		switch v.(type) {
+		case string:
+			t = "string"
+		case bool:
+			t = "boolean"
+		case int64, uint64, json.Number:
+			t = "integer"
+		default:
+			t = "number"
+		}
+		if !slices.Contains(types, t) {
+			types = append(types, t)
+		}
+	}
+	if slices.Contains(types, "integer") && slices.Contains(types, "number") {
+		types = slices.DeleteFunc(types, func(t string) bool { return t == "integer" })
+	}
+	return types
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,112 @@
+// Package openapi exports the generated typescript types as the component
+// schemas of an OpenAPI 3.1 document. The schemas are built from the same
+// nodes as the typescript, so apply any mutations before exporting.
+package openapi
+
+import (
+	"encoding/json"
+
+	"github.com/coder/guts"
+)
+
+// Version is the OpenAPI version of the generated documents.
+const Version = "3.1.0"
+
+// Document is an OpenAPI document that only includes component schemas.
+type Document struct {
+	OpenAPI    string     `json:"openapi"`
+	Info       Info       `json:"info"`
+	Components Components `json:"components"`
+}
+
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// Schema is a JSON Schema (draft 2020-12), as used by OpenAPI 3.1.
+// Only the keywords that can be generated from the typescript types are
+// included.
+type Schema struct {
+	Ref         string `json:"$ref,omitempty"`
+	Type        Types  `json:"type,omitempty"`
+	Format      string `json:"format,omitempty"`
+	Description string `json:"description,omitempty"`
+	Deprecated  bool   `json:"deprecated,omitempty"`
+	// Const is any json value. A nil value is not set, use 'Enum' for null.
+	Const any   `json:"const,omitempty"`
+	Enum  []any `json:"enum,omitempty"`
+
+	MinLength        *int     `json:"minLength,omitempty"`
+	MaxLength        *int     `json:"maxLength,omitempty"`
+	Pattern          string   `json:"pattern,omitempty"`
+	Minimum          *float64 `json:"minimum,omitempty"`
+	Maximum          *float64 `json:"maximum,omitempty"`
+	ExclusiveMinimum *float64 `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum *float64 `json:"exclusiveMaximum,omitempty"`
+
+	Items       *Schema   `json:"items,omitempty"`
+	PrefixItems []*Schema `json:"prefixItems,omitempty"`
+	MinItems    *int      `json:"minItems,omitempty"`
+	MaxItems    *int      `json:"maxItems,omitempty"`
+
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+
+	AnyOf []*Schema `json:"anyOf,omitempty"`
+	AllOf []*Schema `json:"allOf,omitempty"`
+}
+
+// Types is the 'type' keyword. A single type is encoded as a string.
+type Types []string
+
+func (t Types) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+func (t *Types) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = Types{single}
+		return nil
+	}
+	return json.Unmarshal(data, (*[]string)(t))
+}
+
+// NewDocument creates an OpenAPI document with the schemas of all the
+// typescript nodes.
+func NewDocument(info Info, ts *guts.Typescript) (*Document, error) {
+	schemas, err := Schemas(ts)
+	if err != nil {
+		return nil, err
+	}
+	return &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Components: Components{
+			Schemas: schemas,
+		},
+	}, nil
+}
+
+// Schemas converts the typescript nodes into component schemas, keyed by the
+// typescript name. Nodes that are not data, like functions and interfaces
+// with methods, are skipped.
+//
+// OpenAPI has no generics, so generic types are expanded for each concrete
+// instantiation that is referenced. The instantiation is named after the type
+// and its arguments.
+//
+//	Page<User> --> Page_User
+func Schemas(ts *guts.Typescript) (map[string]*Schema, error) {
+	c := newConverter(ts)
+	return c.convert()
+}
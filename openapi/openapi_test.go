@@ -0,0 +1,116 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/guts"
+	"github.com/coder/guts/config"
+	"github.com/coder/guts/openapi"
+)
+
+// updateGoldenFiles is a flag that can be set to update golden files.
+var updateGoldenFiles = flag.Bool("update", false, "Update golden files")
+
+func TestNewDocument(t *testing.T) {
+	t.Parallel()
+
+	gen, err := guts.NewGolangParser()
+	require.NoError(t, err, "new convert")
+	gen.PreserveComments()
+	gen.ValidationConstraints(guts.ConstraintOptions{})
+
+	err = gen.IncludeGenerate("github.com/coder/guts/testdata/openapi")
+	require.NoError(t, err, "include")
+	gen.IncludeCustomDeclaration(config.StandardMappings())
+
+	ts, err := gen.ToTypescript()
+	require.NoError(t, err, "to typescript")
+	ts.ApplyMutations(
+		config.ReadOnly,
+	)
+
+	doc, err := openapi.NewDocument(openapi.Info{Title: "Test", Version: "1.0.0"}, ts)
+	require.NoError(t, err, "new document")
+
+	output, err := json.MarshalIndent(doc, "", "  ")
+	require.NoError(t, err, "marshal")
+
+	golden := filepath.Join("..", "testdata", "openapi", "openapi.json")
+	if *updateGoldenFiles {
+		// nolint:gosec
+		err := os.WriteFile(golden, append(output, '\n'), 0o644)
+		require.NoError(t, err, "write golden file")
+		return
+	}
+
+	expected, err := os.ReadFile(golden)
+	require.NoError(t, err, "read golden file")
+	require.JSONEq(t, string(expected), string(output))
+
+	// Every reference must resolve to a schema.
+	refs := make(map[string]bool)
+	collectRefs(doc.Components.Schemas, refs)
+	for ref := range refs {
+		name := ref[len("#/components/schemas/"):]
+		require.Containsf(t, doc.Components.Schemas, name, "reference %q", ref)
+	}
+}
+
+func collectRefs(schemas map[string]*openapi.Schema, refs map[string]bool) {
+	var visit func(s *openapi.Schema)
+	visit = func(s *openapi.Schema) {
+		if s == nil {
+			return
+		}
+		if s.Ref != "" {
+			refs[s.Ref] = true
+		}
+		visit(s.Items)
+		visit(s.AdditionalProperties)
+		for _, list := range [][]*openapi.Schema{s.PrefixItems, s.AnyOf, s.AllOf} {
+			for _, child := range list {
+				visit(child)
+			}
+		}
+		for _, child := range s.Properties {
+			visit(child)
+		}
+	}
+	for _, s := range schemas {
+		visit(s)
+	}
+}
+
+func TestNewDocumentConstObjects(t *testing.T) {
+	t.Parallel()
+
+	document := func(muts ...guts.MutationFunc) *openapi.Document {
+		gen, err := guts.NewGolangParser()
+		require.NoError(t, err, "new convert")
+		gen.PreserveComments()
+
+		err = gen.IncludeGenerate("github.com/coder/guts/testdata/openapi")
+		require.NoError(t, err, "include")
+		gen.IncludeCustomDeclaration(config.StandardMappings())
+
+		ts, err := gen.ToTypescript()
+		require.NoError(t, err, "to typescript")
+		ts.ApplyMutations(muts...)
+
+		doc, err := openapi.NewDocument(openapi.Info{Title: "Test", Version: "1.0.0"}, ts)
+		require.NoError(t, err, "new document")
+		return doc
+	}
+
+	// The enum style does not change the schema.
+	expected := document()
+	actual := document(config.EnumAsConstObjects)
+	require.Equal(t, expected.Components.Schemas, actual.Components.Schemas)
+	require.Contains(t, actual.Components.Schemas, "Role")
+}
@@ -0,0 +1,262 @@
+package guts
+
+import (
+	"sort"
+
+	"github.com/coder/guts/bindings"
+	"github.com/coder/guts/bindings/walk"
+)
+
+// SerializeOrder determines the order top level nodes are serialized in.
+// The map key is the typescript identifier of the node.
+// It can be passed to 'Typescript.SerializeInOrder'.
+type SerializeOrder func(nodes map[string]bindings.Node) []bindings.Node
+
+// OrderAlphabetical sorts all nodes alphabetically by their typescript name.
+// This is the default order used by 'Typescript.Serialize'.
+func OrderAlphabetical(nodes map[string]bindings.Node) []bindings.Node {
+	names := sortedKeys(nodes)
+	order := make([]bindings.Node, 0, len(names))
+	for _, k := range names {
+		order = append(order, nodes[k])
+	}
+	return order
+}
+
+// OrderBySource sorts all nodes by the golang file and position they were
+// declared in. Nodes without a golang source, such as nodes added by
+// mutations, are placed at the end in alphabetical order.
+func OrderBySource(nodes map[string]bindings.Node) []bindings.Node {
+	names := sortedKeys(nodes)
+	sort.SliceStable(names, func(i, j int) bool {
+		return sourceLess(nodes[names[i]], nodes[names[j]])
+	})
+
+	order := make([]bindings.Node, 0, len(names))
+	for _, k := range names {
+		order = append(order, nodes[k])
+	}
+	return order
+}
+
+// OrderByPackage groups all nodes by their golang package. Packages are sorted
+// by their import path, and the nodes within a package are in source order.
+// Nodes without a package are placed at the end.
+func OrderByPackage(nodes map[string]bindings.Node) []bindings.Node {
+	names := sortedKeys(nodes)
+	sort.SliceStable(names, func(i, j int) bool {
+		a, b := nodePackage(nodes[names[i]]), nodePackage(nodes[names[j]])
+		if a != b {
+			if a == "" || b == "" {
+				// Empty packages go last
+				return b == ""
+			}
+			return a < b
+		}
+		return sourceLess(nodes[names[i]], nodes[names[j]])
+	})
+
+	order := make([]bindings.Node, 0, len(names))
+	for _, k := range names {
+		order = append(order, nodes[k])
+	}
+	return order
+}
+
+// OrderTopological places all dependencies before their dependents. Nodes that
+// have no ordering constraint between them are kept in source order.
+// Circular references cannot be ordered, so the cycle is broken at the
+// first node in source order.
+func OrderTopological(nodes map[string]bindings.Node) []bindings.Node {
+	// Start from source order, so independent nodes remain in a stable,
+	// readable order.
+	names := sortedKeys(nodes)
+	sort.SliceStable(names, func(i, j int) bool {
+		return sourceLess(nodes[names[i]], nodes[names[j]])
+	})
+
+	dependencies := make(map[string][]string, len(names))
+	for _, name := range names {
+		refs := &referenceCollector{refs: make(map[string]struct{})}
+		walk.Walk(refs, nodes[name])
+		for ref := range refs.refs {
+			if _, ok := nodes[ref]; ok && ref != name {
+				dependencies[name] = append(dependencies[name], ref)
+			}
+		}
+		// Map iteration is random, keep the output deterministic.
+		sort.Strings(dependencies[name])
+	}
+
+	order := make([]bindings.Node, 0, len(names))
+	// 0 = unvisited, 1 = visiting, 2 = done
+	state := make(map[string]int, len(names))
+	var visit func(name string)
+	visit = func(name string) {
+		if state[name] != 0 {
+			// Either already placed, or a cycle. Cycles are broken here.
+			return
+		}
+		state[name] = 1
+		for _, dep := range dependencies[name] {
+			visit(dep)
+		}
+		state[name] = 2
+		order = append(order, nodes[name])
+	}
+
+	for _, name := range names {
+		visit(name)
+	}
+	return order
+}
+
+// valuesAfterReferences moves variable statements after the declarations
+// they reference. Values are evaluated at runtime, so the enums and objects
+// used in them must be declared first, regardless of the order. Circular
+// references keep the given order.
+func valuesAfterReferences(order []bindings.Node) []bindings.Node {
+	declared := make(map[string]struct{}, len(order))
+	for _, node := range order {
+		if ident, ok := declarationIdentifier(node); ok {
+			declared[ident.Qualified()] = struct{}{}
+		}
+	}
+
+	// waiting are the declarations each deferred value still needs.
+	waiting := make(map[bindings.Node]map[string]struct{})
+	placed := make(map[string]struct{}, len(order))
+	sorted := make([]bindings.Node, 0, len(order))
+	var deferred []bindings.Node
+	place := func(node bindings.Node) {
+		sorted = append(sorted, node)
+		if ident, ok := declarationIdentifier(node); ok {
+			placed[ident.Qualified()] = struct{}{}
+		}
+	}
+
+	for _, node := range order {
+		if _, ok := node.(*bindings.VariableStatement); ok {
+			ident, _ := declarationIdentifier(node)
+			refs := &referenceCollector{refs: make(map[string]struct{}), valuesOnly: true}
+			walk.Walk(refs, node)
+			needs := make(map[string]struct{})
+			for ref := range refs.refs {
+				_, isDeclared := declared[ref]
+				_, isPlaced := placed[ref]
+				if isDeclared && !isPlaced && ref != ident.Qualified() {
+					needs[ref] = struct{}{}
+				}
+			}
+			if len(needs) > 0 {
+				waiting[node] = needs
+				deferred = append(deferred, node)
+				continue
+			}
+		}
+		place(node)
+
+		// Place any deferred values that are no longer waiting.
+		for i := 0; i < len(deferred); i++ {
+			value := deferred[i]
+			for ref := range waiting[value] {
+				if _, ok := placed[ref]; ok {
+					delete(waiting[value], ref)
+				}
+			}
+			if len(waiting[value]) == 0 {
+				deferred = append(deferred[:i], deferred[i+1:]...)
+				place(value)
+				// A placed value can release values before it.
+				i = -1
+			}
+		}
+	}
+	// Circular references cannot be ordered.
+	return append(sorted, deferred...)
+}
+
+func sortedKeys(nodes map[string]bindings.Node) []string {
+	names := make([]string, 0, len(nodes))
+	for k := range nodes {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// sourceLess orders nodes by their golang source location. Nodes without a
+// source location are always last.
+func sourceLess(a, b bindings.Node) bool {
+	as, aok := nodeSource(a)
+	bs, bok := nodeSource(b)
+	if !aok || !bok {
+		return aok && !bok
+	}
+
+	if as.Position.Filename != bs.Position.Filename {
+		return as.Position.Filename < bs.Position.Filename
+	}
+	return as.Position.Offset < bs.Position.Offset
+}
+
+func nodeSource(node bindings.Node) (bindings.Source, bool) {
+	located, ok := node.(bindings.Locatable)
+	if !ok {
+		return bindings.Source{}, false
+	}
+	src := located.Location()
+	return src, src.Position.IsValid()
+}
+
+func nodePackage(node bindings.Node) string {
+	ident, ok := declarationIdentifier(node)
+	if !ok {
+		return ""
+	}
+	return ident.PkgName()
+}
+
+// declarationIdentifier returns the identifier of a top level declaration.
+func declarationIdentifier(node bindings.Node) (bindings.Identifier, bool) {
+	switch node := node.(type) {
+	case *bindings.Interface:
+		return node.Name, true
+	case *bindings.Alias:
+		return node.Name, true
+	case *bindings.Enum:
+		return node.Name, true
+	case *bindings.FunctionDeclaration:
+		return node.Name, true
+	case *bindings.VariableStatement:
+		if node.Declarations != nil && len(node.Declarations.Declarations) > 0 {
+			return node.Declarations.Declarations[0].Name, true
+		}
+	}
+	return bindings.Identifier{}, false
+}
+
+// referenceCollector collects all references to other types.
+type referenceCollector struct {
+	refs map[string]struct{}
+	// valuesOnly only collects references that are evaluated at runtime.
+	valuesOnly bool
+}
+
+func (r *referenceCollector) Visit(node bindings.Node) walk.Visitor {
+	switch node := node.(type) {
+	case *bindings.ReferenceType:
+		if !r.valuesOnly {
+			r.refs[node.Name.Qualified()] = struct{}{}
+		}
+	case *bindings.TypeQuery:
+		if !r.valuesOnly {
+			r.refs[node.Name.Qualified()] = struct{}{}
+		}
+	case *bindings.PropertyAccess:
+		r.refs[node.Object.Qualified()] = struct{}{}
+	case *bindings.IdentifierExpression:
+		r.refs[node.Name.Qualified()] = struct{}{}
+	}
+	return r
+}
@@ -0,0 +1,154 @@
+package guts
+
+import (
+	"go/types"
+	"regexp"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/coder/guts/bindings"
+)
+
+// GenericOverride produces the typescript type of a golang type, given the
+// typescript types of its type arguments. Non generic types have no
+// arguments.
+// Eg: "database/sql.Null[T]" -> "T | null"
+type GenericOverride func(args ...bindings.ExpressionType) bindings.ExpressionType
+
+// OverrideRule overrides every golang type it matches. Use 'OverrideGlob',
+// 'OverrideRegex' or 'OverridePredicate' to create a rule.
+type OverrideRule struct {
+	match func(ty types.Type) (GenericOverride, bool)
+}
+
+// OverrideGlob matches the fully qualified name of named types. '*' matches
+// any characters, and '?' matches a single character. Generic types are
+// matched without their type arguments.
+// Eg: "github.com/your/repo/dbtypes.*"
+func OverrideGlob(pattern string, override GenericOverride) (OverrideRule, error) {
+	expr := regexp.QuoteMeta(pattern)
+	expr = strings.ReplaceAll(expr, `\*`, ".*")
+	expr = strings.ReplaceAll(expr, `\?`, ".")
+	return OverrideRegex("^"+expr+"$", override)
+}
+
+// OverrideRegex matches the fully qualified name of named types. The regex
+// is not anchored. Generic types are matched without their type arguments.
+// Eg: `^database/sql\.Null`
+func OverrideRegex(pattern string, override GenericOverride) (OverrideRule, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return OverrideRule{}, xerrors.Errorf("compile %q: %w", pattern, err)
+	}
+	return OverrideRule{
+		match: func(ty types.Type) (GenericOverride, bool) {
+			name, ok := qualifiedTypeName(ty)
+			if !ok || !re.MatchString(name) {
+				return nil, false
+			}
+			return override, true
+		},
+	}, nil
+}
+
+// OverridePredicate matches any golang type the predicate returns an
+// override for, like all types implementing an interface.
+func OverridePredicate(predicate func(ty types.Type) (TypeOverride, bool)) OverrideRule {
+	return OverrideRule{
+		match: func(ty types.Type) (GenericOverride, bool) {
+			override, ok := predicate(ty)
+			if !ok {
+				return nil, false
+			}
+			return func(...bindings.ExpressionType) bindings.ExpressionType {
+				return override()
+			}, true
+		},
+	}
+}
+
+// IncludeOverrideRules adds rules to override golang types. The rules are
+// checked in order, and the first match is used. The exact overrides of
+// 'IncludeCustom' take precedence over all rules.
+func (p *GoParser) IncludeOverrideRules(rules ...OverrideRule) *GoParser {
+	p.overrideRules = append(p.overrideRules, rules...)
+	return p
+}
+
+// customOverride returns the override of a golang type, from the exact
+// overrides or the override rules.
+func (p *GoParser) customOverride(ty types.Type) (GenericOverride, bool) {
+	if custom, ok := p.typeOverrides[ty.String()]; ok {
+		return func(...bindings.ExpressionType) bindings.ExpressionType {
+			return custom()
+		}, true
+	}
+
+	for _, rule := range p.overrideRules {
+		if override, ok := rule.match(ty); ok {
+			return override, true
+		}
+	}
+	return nil, false
+}
+
+// customType returns the typescript type of an overridden golang type. The
+// type arguments of generic instantiations are passed to the override. A
+// generic declaration passes its own type parameters.
+func (ts *Typescript) customType(ty types.Type) (parsedType, bool, error) {
+	override, ok := ts.parsed.customOverride(ty)
+	if !ok {
+		return parsedType{}, false, nil
+	}
+
+	var generics []types.Type
+	if named, ok := ty.(interface {
+		TypeArgs() *types.TypeList
+		TypeParams() *types.TypeParamList
+	}); ok {
+		for i := 0; i < named.TypeArgs().Len(); i++ {
+			generics = append(generics, named.TypeArgs().At(i))
+		}
+		if named.TypeArgs().Len() == 0 {
+			for i := 0; i < named.TypeParams().Len(); i++ {
+				generics = append(generics, named.TypeParams().At(i))
+			}
+		}
+	}
+
+	parsed := parsedType{}
+	args := make([]bindings.ExpressionType, 0, len(generics))
+	for _, generic := range generics {
+		arg, err := ts.typescriptType(generic)
+		if err != nil {
+			return parsedType{}, false, xerrors.Errorf("override argument %q: %w", generic.String(), err)
+		}
+		args = append(args, arg.Value)
+		parsed.TypeParameters = append(parsed.TypeParameters, arg.TypeParameters...)
+		parsed.RaisedComments = append(parsed.RaisedComments, arg.RaisedComments...)
+		parsed.RaisedTags = append(parsed.RaisedTags, arg.RaisedTags...)
+	}
+	parsed.Value = override(args...)
+	return parsed, true, nil
+}
+
+// qualifiedTypeName is the fully qualified name of a named type, without
+// any type arguments. Eg: "database/sql.Null"
+func qualifiedTypeName(ty types.Type) (string, bool) {
+	var obj *types.TypeName
+	switch ty := ty.(type) {
+	case *types.Named:
+		obj = ty.Obj()
+	case *types.Alias:
+		obj = ty.Obj()
+	case *types.Basic:
+		return ty.Name(), true
+	default:
+		return "", false
+	}
+	if obj.Pkg() == nil {
+		return obj.Name(), true
+	}
+	return obj.Pkg().Path() + "." + obj.Name(), true
+}
@@ -0,0 +1,293 @@
+package guts
+
+import (
+	"go/types"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/coder/guts/bindings"
+)
+
+// Protobuf generates the protojson wire shape of 'protoc-gen-go' messages.
+//   - Fields use the protojson names from the 'protobuf' struct tag.
+//   - All fields are optional, as protojson omits unpopulated fields.
+//   - Messages are never 'null', unset messages are omitted.
+//   - Oneof fields are flattened into the message, as they are in json.
+//   - Enums are the string names of the values.
+//   - 64 bit integers of message fields are strings. The int64 policy still
+//     applies to all other types.
+//   - Well known types, like 'timestamppb.Timestamp', use their json mapping.
+//
+// This must be called before any custom mappings that should take priority.
+func (p *GoParser) Protobuf() *GoParser {
+	p.protobuf = true
+	p.IncludeCustomDeclaration(protobufMappings())
+	return p
+}
+
+// protobufTag is the parsed 'protobuf' struct tag of a message field.
+//
+//	protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3"
+type protobufTag struct {
+	number int
+	// name is the protojson name of the field.
+	name  string
+	oneof bool
+}
+
+func parseProtobufTag(tag string) (protobufTag, bool) {
+	value, ok := reflect.StructTag(tag).Lookup("protobuf")
+	if !ok {
+		return protobufTag{}, false
+	}
+
+	var parsed protobufTag
+	var protoName string
+	for i, part := range strings.Split(value, ",") {
+		switch {
+		case i == 1:
+			parsed.number, _ = strconv.Atoi(part)
+		case strings.HasPrefix(part, "name="):
+			protoName = strings.TrimPrefix(part, "name=")
+		case strings.HasPrefix(part, "json="):
+			parsed.name = strings.TrimPrefix(part, "json=")
+		case part == "oneof":
+			parsed.oneof = true
+		}
+	}
+	if parsed.name == "" {
+		// The json name is only included if it is different.
+		parsed.name = protoName
+	}
+	return parsed, parsed.name != ""
+}
+
+// protobufFields returns the fields of a message struct field. Regular fields
+// are a single field, oneof fields are a field for each option. The generated
+// comments of oneof fields are replaced. The golang field of each field is
+// returned too, which is the wrapper field for oneof options. Nil is returned
+// if the field is not a protobuf field.
+func (ts *Typescript) protobufFields(field *types.Var, tag string) ([]*bindings.PropertySignature, []GoField, error) {
+	if parsed, ok := parseProtobufTag(tag); ok {
+		prop, err := ts.protobufField(parsed.name, field.Type())
+		if err != nil {
+			return nil, nil, err
+		}
+		if ts.preserveComments {
+			prop.AppendComments(ts.parsed.CommentForObject(field))
+		}
+		return []*bindings.PropertySignature{prop}, []GoField{{Var: field, Tag: reflect.StructTag(tag)}}, nil
+	}
+
+	oneof, ok := reflect.StructTag(tag).Lookup("protobuf_oneof")
+	if !ok {
+		return nil, nil, nil
+	}
+
+	wrappers := ts.parsed.oneofWrappers(field.Type())
+	if len(wrappers) == 0 {
+		return nil, nil, xerrors.Errorf("oneof %q has no wrapper types", oneof)
+	}
+
+	names := make([]string, 0, len(wrappers))
+	for _, w := range wrappers {
+		names = append(names, "'"+w.tag.name+"'")
+	}
+	comment := "oneof " + oneof + ": only one of " + strings.Join(names, ", ") + " is set"
+
+	props := make([]*bindings.PropertySignature, 0, len(wrappers))
+	goFields := make([]GoField, 0, len(wrappers))
+	for _, w := range wrappers {
+		prop, err := ts.protobufField(w.tag.name, w.field.Type())
+		if err != nil {
+			return nil, nil, xerrors.Errorf("oneof %q: %w", oneof, err)
+		}
+		prop.LeadingComment(comment)
+		props = append(props, prop)
+		// Each option is generated from the field of its wrapper.
+		goFields = append(goFields, GoField{Var: w.field, Tag: w.structTag})
+	}
+	return props, goFields, nil
+}
+
+func (ts *Typescript) protobufField(name string, ty types.Type) (*bindings.PropertySignature, error) {
+	// Protojson encodes 64 bit integers as strings, whatever the policy is.
+	ts.fieldInt64Policy = Int64String
+	tsType, err := ts.typescriptType(protobufWireType(ty))
+	ts.fieldInt64Policy = ""
+	if err != nil {
+		return nil, xerrors.Errorf("field %q: %w", name, err)
+	}
+
+	if union, ok := tsType.Value.(*bindings.UnionType); ok {
+		// Empty maps are omitted, not 'null'.
+		members := slices.DeleteFunc(slices.Clone(union.Types), func(t bindings.ExpressionType) bool {
+			_, isNull := t.(*bindings.Null)
+			return isNull
+		})
+		if len(members) == 1 {
+			tsType.Value = members[0]
+		} else if len(members) > 1 {
+			tsType.Value = bindings.Union(members...)
+		}
+	}
+
+	prop := &bindings.PropertySignature{
+		Name:          name,
+		Modifiers:     []bindings.Modifier{},
+		QuestionToken: true,
+		Type:          tsType.Value,
+	}
+	for _, c := range tsType.RaisedComments {
+		prop.LeadingComment(c)
+	}
+	for _, tag := range tsType.RaisedTags {
+		prop.JSDocTag(tag)
+	}
+	return prop, nil
+}
+
+// protobufWireType removes the pointers from a field type. Protojson never
+// encodes messages, or the elements of lists and maps, as 'null'.
+func protobufWireType(ty types.Type) types.Type {
+	switch t := ty.(type) {
+	case *types.Pointer:
+		return t.Elem()
+	case *types.Slice:
+		if ptr, ok := t.Elem().(*types.Pointer); ok {
+			return types.NewSlice(ptr.Elem())
+		}
+	case *types.Map:
+		if ptr, ok := t.Elem().(*types.Pointer); ok {
+			return types.NewMap(t.Key(), ptr.Elem())
+		}
+	}
+	return ty
+}
+
+// oneofWrapper is a struct that wraps a single option of a oneof field.
+//
+//	type User_Email struct {
+//		Email string `protobuf:"bytes,3,opt,name=email,proto3,oneof"`
+//	}
+type oneofWrapper struct {
+	field *types.Var
+	// structTag is the struct tag of the field.
+	structTag reflect.StructTag
+	tag       protobufTag
+}
+
+// oneofWrappers returns the wrapper types of a oneof interface, in field
+// number order.
+func (p *GoParser) oneofWrappers(ty types.Type) []oneofWrapper {
+	intf, ok := ty.Underlying().(*types.Interface)
+	named, isNamed := ty.(*types.Named)
+	if !ok || !isNamed || named.Obj().Pkg() == nil {
+		return nil
+	}
+	pkg, ok := p.Pkgs[named.Obj().Pkg().Path()]
+	if !ok {
+		return nil
+	}
+
+	var wrappers []oneofWrapper
+	scope := pkg.Types.Scope()
+	for _, name := range scope.Names() {
+		obj, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok {
+			continue
+		}
+		field, tag, ok := isOneofWrapper(obj.Type())
+		if !ok || !types.Implements(types.NewPointer(obj.Type()), intf) {
+			continue
+		}
+		st := obj.Type().Underlying().(*types.Struct)
+		wrappers = append(wrappers, oneofWrapper{field: field, structTag: reflect.StructTag(st.Tag(0)), tag: tag})
+	}
+	sort.Slice(wrappers, func(i, j int) bool {
+		return wrappers[i].tag.number < wrappers[j].tag.number
+	})
+	return wrappers
+}
+
+// isOneofWrapper returns the field of a oneof wrapper struct.
+func isOneofWrapper(ty types.Type) (*types.Var, protobufTag, bool) {
+	st, ok := ty.Underlying().(*types.Struct)
+	if !ok || st.NumFields() != 1 {
+		return nil, protobufTag{}, false
+	}
+	tag, ok := parseProtobufTag(st.Tag(0))
+	if !ok || !tag.oneof {
+		return nil, protobufTag{}, false
+	}
+	return st.Field(0), tag, true
+}
+
+// isProtobufEnum returns true for 'protoc-gen-go' generated enums.
+func isProtobufEnum(named *types.Named) bool {
+	return hasMethod(named, "Enum", 0, types.NewPointer(named))
+}
+
+// protobufMappings are the json mappings of the well known types.
+// See https://protobuf.dev/programming-guides/json/
+func protobufMappings() map[string]TypeOverride {
+	const known = "google.golang.org/protobuf/types/known/"
+	keyword := func(k bindings.LiteralKeyword) TypeOverride {
+		return func() bindings.ExpressionType {
+			return ptr(k)
+		}
+	}
+	record := func(value bindings.ExpressionType) TypeOverride {
+		return func() bindings.ExpressionType {
+			return RecordReference(ptr(bindings.KeywordString), bindings.Clone(value))
+		}
+	}
+
+	return map[string]TypeOverride{
+		// RFC 3339, eg: "1972-01-01T10:00:20.021Z"
+		known + "timestamppb.Timestamp": keyword(bindings.KeywordString),
+		// Seconds with an 's' suffix, eg: "1.000340012s"
+		known + "durationpb.Duration": keyword(bindings.KeywordString),
+		// Comma separated paths, eg: "f.fooBar,h"
+		known + "fieldmaskpb.FieldMask": keyword(bindings.KeywordString),
+
+		known + "structpb.Struct": record(ptr(bindings.KeywordUnknown)),
+		known + "structpb.Value":  keyword(bindings.KeywordUnknown),
+		known + "structpb.ListValue": func() bindings.ExpressionType {
+			return bindings.Array(ptr(bindings.KeywordUnknown))
+		},
+		known + "structpb.NullValue": func() bindings.ExpressionType {
+			return &bindings.Null{}
+		},
+
+		known + "emptypb.Empty": record(ptr(bindings.KeywordNever)),
+		// The message fields, and the '@type' url of the message.
+		known + "anypb.Any": func() bindings.ExpressionType {
+			return &bindings.TypeIntersection{
+				Types: []bindings.ExpressionType{
+					&bindings.TypeLiteralNode{
+						Members: []*bindings.PropertySignature{
+							{Name: "@type", Modifiers: []bindings.Modifier{}, Type: ptr(bindings.KeywordString)},
+						},
+					},
+					RecordReference(ptr(bindings.KeywordString), ptr(bindings.KeywordUnknown)),
+				},
+			}
+		},
+
+		known + "wrapperspb.BoolValue":   keyword(bindings.KeywordBoolean),
+		known + "wrapperspb.StringValue": keyword(bindings.KeywordString),
+		known + "wrapperspb.BytesValue":  keyword(bindings.KeywordString),
+		known + "wrapperspb.Int32Value":  keyword(bindings.KeywordNumber),
+		known + "wrapperspb.UInt32Value": keyword(bindings.KeywordNumber),
+		known + "wrapperspb.FloatValue":  keyword(bindings.KeywordNumber),
+		known + "wrapperspb.DoubleValue": keyword(bindings.KeywordNumber),
+		known + "wrapperspb.Int64Value":  keyword(bindings.KeywordString),
+		known + "wrapperspb.UInt64Value": keyword(bindings.KeywordString),
+	}
+}
@@ -0,0 +1,395 @@
+package guts
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"log/slog"
+	"maps"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/coder/guts/bindings"
+)
+
+// unionAnnotation is the comment on a sealed interface that names the json
+// field that discriminates the implementations.
+//
+//	//guts:union type
+//	type Event interface {
+//		isEvent()
+//	}
+const unionAnnotation = "guts:union"
+
+// discriminatorAnnotation is the comment on a variant that sets the value of
+// the discriminator field.
+//
+//	//guts:discriminator "created"
+//	type CreatedEvent struct {
+const discriminatorAnnotation = "guts:discriminator"
+
+// sealedUnion is an interface that is generated as a union of all the structs
+// that implement it.
+type sealedUnion struct {
+	// field is the json name of the discriminator field.
+	field    string
+	variants []*types.TypeName
+}
+
+// buildSealedUnions finds all annotated interfaces, and the structs that
+// implement them in the loaded packages. See 'discriminatorValue' for how the
+// discriminator value of each variant is found.
+func (p *GoParser) buildSealedUnions() (map[string]sealedUnion, map[string]map[string]string, error) {
+	unions := make(map[string]sealedUnion)
+	// variants are the discriminator values of each struct, keyed by the
+	// json field name.
+	variants := make(map[string]map[string]string)
+
+	var structs []*types.TypeName
+	for _, pkg := range p.Pkgs {
+		scope := pkg.Types.Scope()
+		for _, name := range scope.Names() {
+			obj, ok := scope.Lookup(name).(*types.TypeName)
+			if !ok || obj.IsAlias() {
+				continue
+			}
+			named, ok := obj.Type().(*types.Named)
+			if !ok || named.TypeParams().Len() > 0 {
+				continue
+			}
+			if _, ok := named.Underlying().(*types.Struct); ok {
+				structs = append(structs, obj)
+			}
+		}
+	}
+	sort.Slice(structs, func(i, j int) bool {
+		return qualifiedName(structs[i]) < qualifiedName(structs[j])
+	})
+
+	for _, pkg := range p.Pkgs {
+		scope := pkg.Types.Scope()
+		for _, name := range scope.Names() {
+			obj, ok := scope.Lookup(name).(*types.TypeName)
+			if !ok {
+				continue
+			}
+			intf, ok := obj.Type().Underlying().(*types.Interface)
+			if !ok {
+				continue
+			}
+			field, ok := unionField(p.CommentForObject(obj))
+			if !ok {
+				continue
+			}
+			if intf.NumMethods() == 0 {
+				return nil, nil, xerrors.Errorf("union %q: interface has no methods", qualifiedName(obj))
+			}
+
+			union := sealedUnion{field: field}
+			for _, st := range structs {
+				if !types.Implements(st.Type(), intf) && !types.Implements(types.NewPointer(st.Type()), intf) {
+					continue
+				}
+
+				union.variants = append(union.variants, st)
+				value, ok, err := p.discriminatorValue(st, field)
+				if err != nil {
+					return nil, nil, xerrors.Errorf("union %q variant %q: %w", qualifiedName(obj), qualifiedName(st), err)
+				}
+				if !ok {
+					// The discriminator field stays a string.
+					continue
+				}
+				if variants[qualifiedName(st)] == nil {
+					variants[qualifiedName(st)] = make(map[string]string)
+				}
+				variants[qualifiedName(st)][field] = value
+			}
+			if len(union.variants) == 0 {
+				return nil, nil, xerrors.Errorf("union %q: no structs implement the interface", qualifiedName(obj))
+			}
+			unions[qualifiedName(obj)] = union
+		}
+	}
+	return unions, variants, nil
+}
+
+// discriminatorValue returns the discriminator value of a variant. In order
+// of precedence, the value is:
+//   - The value of the annotation on the struct. //guts:discriminator "created"
+//   - A constant of the field's type, named by the struct and field name.
+//     const CreatedEventType EventType = "created"
+//   - The only constant the field is set to in composite literals of the
+//     loaded packages. CreatedEvent{Type: "created"}
+//
+// The field can be promoted from an embedded struct, as encoding/json puts it
+// at the top level. If the value cannot be found, a warning is logged and
+// false is returned.
+func (p *GoParser) discriminatorValue(obj *types.TypeName, field string) (string, bool, error) {
+	st := obj.Type().Underlying().(*types.Struct)
+	promoted, embedded, ok := jsonField(st, field)
+	if !ok {
+		return "", false, xerrors.Errorf("no discriminator field %q", field)
+	}
+	goField := promoted.Var
+
+	value, ok, err := annotatedDiscriminator(p.CommentForObject(obj))
+	if err != nil || ok {
+		return value, ok, err
+	}
+
+	// const CreatedEventType EventType = "created"
+	if cnst, ok := obj.Pkg().Scope().Lookup(obj.Name() + goField.Name()).(*types.Const); ok &&
+		types.Identical(cnst.Type(), goField.Type()) && cnst.Val().Kind() == constant.String {
+		return constant.StringVal(cnst.Val()), true, nil
+	}
+
+	// A promoted field is set through the embedded structs.
+	// CreatedEvent{Base: Base{Type: "created"}}
+	keys := make([]string, 0, len(embedded)+1)
+	for _, e := range embedded {
+		keys = append(keys, e.Name())
+	}
+	keys = append(keys, goField.Name())
+
+	var values []string
+	for _, path := range slices.Sorted(maps.Keys(p.Pkgs)) {
+		pkg := p.Pkgs[path]
+		for _, file := range pkg.Syntax {
+			ast.Inspect(file, func(n ast.Node) bool {
+				lit, ok := n.(*ast.CompositeLit)
+				if !ok || !types.Identical(pkg.TypesInfo.TypeOf(lit), obj.Type()) {
+					return true
+				}
+				value := literalField(pkg.TypesInfo, lit, keys)
+				if value != nil && value.Kind() == constant.String && !slices.Contains(values, constant.StringVal(value)) {
+					values = append(values, constant.StringVal(value))
+				}
+				return true
+			})
+		}
+	}
+
+	if len(values) != 1 {
+		slog.Warn("discriminator value of a union variant could not be determined, the field is generated as a string. "+
+			"Set the value with a '//guts:discriminator \"value\"' annotation on the struct.",
+			slog.String("variant", qualifiedName(obj)),
+			slog.String("field", goField.Name()),
+			slog.Any("found", values),
+		)
+		return "", false, nil
+	}
+	return values[0], true, nil
+}
+
+// literalField returns the constant value of a field in a composite literal.
+// The keys are the names of the embedded structs that promote the field, and
+// then the field name.
+func literalField(info *types.Info, lit *ast.CompositeLit, keys []string) constant.Value {
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		key, ok := kv.Key.(*ast.Ident)
+		if !ok || key.Name != keys[0] {
+			continue
+		}
+		if len(keys) == 1 {
+			return info.Types[kv.Value].Value
+		}
+
+		value := ast.Unparen(kv.Value)
+		if unary, ok := value.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+			value = ast.Unparen(unary.X)
+		}
+		if inner, ok := value.(*ast.CompositeLit); ok {
+			return literalField(info, inner, keys[1:])
+		}
+	}
+	return nil
+}
+
+// annotatedDiscriminator returns the quoted value of the discriminator
+// annotation.
+func annotatedDiscriminator(comments []bindings.SyntheticComment) (string, bool, error) {
+	for _, c := range comments {
+		quoted, ok := strings.CutPrefix(strings.TrimSpace(c.Text), discriminatorAnnotation)
+		if !ok {
+			continue
+		}
+		value, err := strconv.Unquote(strings.TrimSpace(quoted))
+		if err != nil {
+			return "", false, xerrors.Errorf("annotation %q: the value must be a quoted string", c.Text)
+		}
+		return value, true, nil
+	}
+	return "", false, nil
+}
+
+// unionField returns the discriminator field from the union annotation.
+func unionField(comments []bindings.SyntheticComment) (string, bool) {
+	for _, c := range comments {
+		field, ok := strings.CutPrefix(strings.TrimSpace(c.Text), unionAnnotation)
+		if !ok {
+			continue
+		}
+		field = strings.TrimSpace(field)
+		return field, field != ""
+	}
+	return "", false
+}
+
+// buildSealedUnion generates the union of all the variants of an interface.
+func (ts *Typescript) buildSealedUnion(obj types.Object, union sealedUnion) (*bindings.Alias, error) {
+	variants := make([]bindings.ExpressionType, 0, len(union.variants))
+	for _, variant := range union.variants {
+		ty, err := ts.typescriptType(variant.Type())
+		if err != nil {
+			return nil, xerrors.Errorf("variant %q: %w", variant.Name(), err)
+		}
+		variants = append(variants, ty.Value)
+	}
+
+	alias := &bindings.Alias{
+		Name:       ts.parsed.Identifier(obj),
+		Modifiers:  []bindings.Modifier{},
+		Type:       bindings.Union(variants...),
+		Parameters: []*bindings.TypeParameter{},
+		Source:     ts.location(obj),
+	}
+	if ts.preserveComments {
+		alias.AppendComments(withoutAnnotation(ts.parsed.CommentForObject(obj), unionAnnotation))
+	}
+	return alias, nil
+}
+
+// withoutAnnotation removes an annotation from the doc comments.
+func withoutAnnotation(cmts []bindings.SyntheticComment, annotation string) []bindings.SyntheticComment {
+	cmts = slices.DeleteFunc(cmts, func(c bindings.SyntheticComment) bool {
+		return strings.HasPrefix(strings.TrimSpace(c.Text), annotation)
+	})
+	// Directives are separated from the doc comment by an empty line.
+	for len(cmts) > 0 && strings.TrimSpace(cmts[len(cmts)-1].Text) == "" {
+		cmts = cmts[:len(cmts)-1]
+	}
+	return cmts
+}
+
+// discriminator returns the literal type of a struct field, if the struct is
+// a variant of a sealed union and the field is the discriminator.
+func (p *GoParser) discriminator(obj types.Object, field string) (bindings.ExpressionType, bool) {
+	value, ok := p.sealedVariants[qualifiedName(obj)][field]
+	if !ok {
+		return nil, false
+	}
+	return &bindings.LiteralType{Value: value}, true
+}
+
+// promotedDiscriminators returns the discriminator fields of a variant that
+// are promoted from an embedded struct. The embedded struct is inherited with
+// the field as a string, so the variant narrows it to the discriminator value.
+func (ts *Typescript) promotedDiscriminators(obj types.Object, st *types.Struct, fields []*bindings.PropertySignature) []*bindings.PropertySignature {
+	values := ts.parsed.sealedVariants[qualifiedName(obj)]
+	var props []*bindings.PropertySignature
+	for _, name := range slices.Sorted(maps.Keys(values)) {
+		if slices.ContainsFunc(fields, func(f *bindings.PropertySignature) bool { return f.Name == name }) {
+			continue
+		}
+		goField, path, ok := jsonField(st, name)
+		if !ok || len(path) == 0 {
+			continue
+		}
+		prop := &bindings.PropertySignature{
+			Name:      name,
+			Modifiers: []bindings.Modifier{},
+			Type:      &bindings.LiteralType{Value: values[name]},
+		}
+		ts.fields[prop] = goField
+		props = append(props, prop)
+	}
+	return props
+}
+
+// jsonField returns the struct field with a json name, and the embedded
+// fields that promote it. Like encoding/json, the least nested field is used,
+// and fields that are nested equally are ambiguous unless only one of them
+// has a json name.
+func jsonField(st *types.Struct, name string) (GoField, []*types.Var, bool) {
+	type embedding struct {
+		st   *types.Struct
+		path []*types.Var
+	}
+	type match struct {
+		field  GoField
+		path   []*types.Var
+		tagged bool
+	}
+
+	visited := make(map[types.Type]bool)
+	current := []embedding{{st: st}}
+	for len(current) > 0 {
+		var next []embedding
+		var matches []match
+		for _, e := range current {
+			for i := 0; i < e.st.NumFields(); i++ {
+				field := e.st.Field(i)
+				tag := reflect.StructTag(e.st.Tag(i)).Get("json")
+				if tag == "-" {
+					continue
+				}
+				if field.Embedded() && tag == "" {
+					ty := field.Type()
+					if ptr, ok := ty.(*types.Pointer); ok {
+						ty = ptr.Elem()
+					}
+					if embedded, ok := ty.Underlying().(*types.Struct); ok {
+						if !visited[ty] {
+							visited[ty] = true
+							next = append(next, embedding{st: embedded, path: append(slices.Clone(e.path), field)})
+						}
+						continue
+					}
+				}
+				if !field.Exported() || jsonFieldName(field, e.st.Tag(i)) != name {
+					continue
+				}
+				tagged, _, _ := strings.Cut(tag, ",")
+				matches = append(matches, match{
+					field:  GoField{Var: field, Tag: reflect.StructTag(e.st.Tag(i))},
+					path:   e.path,
+					tagged: tagged != "",
+				})
+			}
+		}
+
+		switch {
+		case len(matches) == 1:
+			return matches[0].field, matches[0].path, true
+		case len(matches) > 1:
+			tagged := slices.DeleteFunc(matches, func(m match) bool { return !m.tagged })
+			if len(tagged) == 1 {
+				return tagged[0].field, tagged[0].path, true
+			}
+			return GoField{}, nil, false
+		}
+		current = next
+	}
+	return GoField{}, nil, false
+}
+
+// jsonFieldName is the name of a struct field in json.
+func jsonFieldName(field *types.Var, tag string) string {
+	name := reflect.StructTag(tag).Get("json")
+	name, _, _ = strings.Cut(name, ",")
+	if name == "" {
+		return field.Name()
+	}
+	return name
+}
@@ -37,6 +37,8 @@ func parseExpression(expr string) (bindings.ExpressionType, error) {
 	goParser.fileSet = fs
 	ts := Typescript{
 		typescriptNodes: make(map[string]*typescriptNode),
+		objects:         make(map[string]types.Object),
+		fields:          make(map[*bindings.PropertySignature]GoField),
 		parsed:          goParser, // Intentionally empty
 		serialized:      false,
 	}
@@ -0,0 +1,405 @@
+package guts
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"log/slog"
+	"sort"
+	"strconv"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/coder/guts/bindings"
+)
+
+// StringerEnums treats integer enums that implement 'fmt.Stringer' as string
+// enums. By default, only integer enums that implement 'encoding.TextMarshaler'
+// are treated as string enums, as that is what 'encoding/json' uses. Enable
+// this if your types are marshaled with their 'String()' method in some
+// other way.
+func (p *GoParser) StringerEnums() *GoParser {
+	p.stringerEnums = true
+	return p
+}
+
+// marshalsAsText returns true if the integer type is marshaled to json using
+// its text representation.
+func (p *GoParser) marshalsAsText(named *types.Named) bool {
+	basic, ok := named.Underlying().(*types.Basic)
+	if !ok || basic.Info()&types.IsInteger == 0 {
+		return false
+	}
+
+	if p.protobuf && isProtobufEnum(named) {
+		// Protojson uses the enum value names.
+		return true
+	}
+	bytesErr := []types.Type{types.NewSlice(types.Typ[types.Byte]), types.Universe.Lookup("error").Type()}
+	if hasMethod(named, "MarshalJSON", 0, bytesErr...) {
+		// 'encoding/json' prefers 'MarshalJSON' over 'MarshalText', and the
+		// json it returns is unknown.
+		return false
+	}
+	if hasMethod(named, "MarshalText", 0, bytesErr...) {
+		return true
+	}
+	return p.stringerEnums && hasMethod(named, "String", 0, types.Typ[types.String])
+}
+
+// textEnumNames returns the text name of every constant value of an integer
+// enum that is marshaled as text. Enums with names that cannot be determined
+// return false, and are generated as numeric enums.
+func (p *GoParser) textEnumNames(named *types.Named) (map[int64]string, bool) {
+	if !p.marshalsAsText(named) {
+		return nil, false
+	}
+
+	names, ok := p.enumNames[named.Obj()]
+	if !ok {
+		names = p.findEnumNames(named)
+		p.enumNames[named.Obj()] = names
+	}
+	return names, names != nil
+}
+
+func hasMethod(named *types.Named, name string, params int, results ...types.Type) bool {
+	obj, _, _ := types.LookupFieldOrMethod(types.NewPointer(named), false, named.Obj().Pkg(), name)
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return false
+	}
+
+	sig, ok := fn.Type().(*types.Signature)
+	if !ok || sig.Params().Len() != params || sig.Results().Len() != len(results) {
+		return false
+	}
+	for i, r := range results {
+		if !types.Identical(sig.Results().At(i).Type(), r) {
+			return false
+		}
+	}
+	return true
+}
+
+// enumTextValue returns the text value of an integer enum constant. The text
+// values are found from 'stringer' generated tables, or from the 'String()'
+// method of the type.
+func (p *GoParser) enumTextValue(obj *types.Const) (*bindings.LiteralType, bool) {
+	named, ok := obj.Type().(*types.Named)
+	if !ok {
+		return nil, false
+	}
+	names, ok := p.textEnumNames(named)
+	if !ok {
+		return nil, false
+	}
+
+	value, ok := constant.Int64Val(obj.Val())
+	if !ok {
+		return nil, false
+	}
+
+	name, ok := names[value]
+	if !ok {
+		return nil, false
+	}
+	return &bindings.LiteralType{Value: name}, true
+}
+
+// findEnumNames returns the text name of every constant value of the enum.
+// If any constant does not have a name, nil is returned.
+func (p *GoParser) findEnumNames(named *types.Named) map[int64]string {
+	pkg, ok := p.Pkgs[named.Obj().Pkg().Path()]
+	if !ok {
+		return nil
+	}
+
+	// All distinct values of the enum, sorted.
+	set := make(map[int64]struct{})
+	scope := pkg.Types.Scope()
+	for _, ident := range scope.Names() {
+		cnst, ok := scope.Lookup(ident).(*types.Const)
+		if !ok || !types.Identical(cnst.Type(), named) {
+			continue
+		}
+		value, ok := constant.Int64Val(cnst.Val())
+		if !ok {
+			return nil
+		}
+		set[value] = struct{}{}
+	}
+	values := make([]int64, 0, len(set))
+	for v := range set {
+		values = append(values, v)
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+
+	names, ok := stringerNames(pkg, named.Obj().Name(), values)
+	if !ok && p.protobuf {
+		names, ok = protobufEnumNames(pkg, named.Obj().Name())
+	}
+	if !ok {
+		names, ok = stringMethodNames(pkg, named)
+	}
+	if !ok {
+		slog.Warn("enum is marshaled as text, but the names could not be determined. Using numeric values.",
+			slog.String("type", named.String()))
+		return nil
+	}
+
+	for _, v := range values {
+		if _, ok := names[v]; !ok {
+			slog.Warn("enum is marshaled as text, but a constant has no name. Using numeric values.",
+				slog.String("type", named.String()), slog.Int64("value", v))
+			return nil
+		}
+	}
+	return names
+}
+
+// stringerNames reads the lookup tables generated by
+// 'golang.org/x/tools/cmd/stringer'.
+func stringerNames(pkg *packages.Package, typeName string, values []int64) (map[int64]string, bool) {
+	scope := pkg.Types.Scope()
+	prefix := "_" + typeName
+
+	// More than 10 runs of values uses a map.
+	if table, ok := scope.Lookup(prefix + "_map").(*types.Var); ok {
+		return mapNames(pkg, table)
+	}
+
+	runs := splitIntoRuns(values)
+	names := make(map[int64]string)
+	for i, run := range runs {
+		suffix := ""
+		if len(runs) > 1 {
+			suffix = "_" + strconv.Itoa(i)
+		}
+
+		nameConst, ok := scope.Lookup(prefix + "_name" + suffix).(*types.Const)
+		if !ok || nameConst.Val().Kind() != constant.String {
+			return nil, false
+		}
+		name := constant.StringVal(nameConst.Val())
+
+		if len(run) == 1 && len(runs) > 1 {
+			// Single value runs do not have an index.
+			names[run[0]] = name
+			continue
+		}
+
+		indexVar, ok := scope.Lookup(prefix + "_index" + suffix).(*types.Var)
+		if !ok {
+			return nil, false
+		}
+		lit, ok := varValue(pkg, indexVar).(*ast.CompositeLit)
+		if !ok || len(lit.Elts) != len(run)+1 {
+			return nil, false
+		}
+
+		index := make([]int64, 0, len(lit.Elts))
+		for _, elt := range lit.Elts {
+			v, ok := constInt(pkg, elt)
+			if !ok || v < 0 || v > int64(len(name)) {
+				return nil, false
+			}
+			index = append(index, v)
+		}
+
+		for j, v := range run {
+			if index[j] > index[j+1] {
+				return nil, false
+			}
+			names[v] = name[index[j]:index[j+1]]
+		}
+	}
+	return names, true
+}
+
+// protobufEnumNames reads the '<Enum>_name' table generated by
+// 'protoc-gen-go'.
+func protobufEnumNames(pkg *packages.Package, typeName string) (map[int64]string, bool) {
+	table, ok := pkg.Types.Scope().Lookup(typeName + "_name").(*types.Var)
+	if !ok {
+		return nil, false
+	}
+	return mapNames(pkg, table)
+}
+
+// stringMethodNames looks at a handwritten 'String()' method. Only simple
+// implementations are supported, being a switch statement returning constant
+// strings, or a lookup into a package level map.
+func stringMethodNames(pkg *packages.Package, named *types.Named) (map[int64]string, bool) {
+	obj, _, _ := types.LookupFieldOrMethod(named, true, named.Obj().Pkg(), "String")
+	method, ok := obj.(*types.Func)
+	if !ok {
+		return nil, false
+	}
+
+	var decl *ast.FuncDecl
+	for _, file := range pkg.Syntax {
+		for _, d := range file.Decls {
+			if fd, ok := d.(*ast.FuncDecl); ok && pkg.TypesInfo.Defs[fd.Name] == method {
+				decl = fd
+			}
+		}
+	}
+	if decl == nil || decl.Body == nil {
+		return nil, false
+	}
+
+	names := make(map[int64]string)
+	found := false
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.CaseClause:
+			// case Foo: return "foo"
+			var name string
+			var hasName bool
+			for _, stmt := range n.Body {
+				if ret, ok := stmt.(*ast.ReturnStmt); ok && len(ret.Results) == 1 {
+					name, hasName = constString(pkg, ret.Results[0])
+					break
+				}
+			}
+			if !hasName {
+				return true
+			}
+			for _, expr := range n.List {
+				if !types.Identical(pkg.TypesInfo.TypeOf(expr), named) {
+					continue
+				}
+				if v, ok := constInt(pkg, expr); ok {
+					names[v] = name
+					found = true
+				}
+			}
+		case *ast.IndexExpr:
+			// return fooNames[f]
+			ident, ok := n.X.(*ast.Ident)
+			if !ok {
+				return true
+			}
+			table, ok := pkg.TypesInfo.Uses[ident].(*types.Var)
+			if !ok || table.Parent() != pkg.Types.Scope() {
+				return true
+			}
+			if tableNames, ok := mapNames(pkg, table); ok {
+				for k, v := range tableNames {
+					names[k] = v
+				}
+				found = true
+			}
+		}
+		return true
+	})
+	return names, found
+}
+
+// mapNames evaluates a package level 'map[T]string' variable.
+func mapNames(pkg *packages.Package, table *types.Var) (map[int64]string, bool) {
+	lit, ok := varValue(pkg, table).(*ast.CompositeLit)
+	if !ok {
+		return nil, false
+	}
+
+	names := make(map[int64]string)
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			return nil, false
+		}
+		key, ok := constInt(pkg, kv.Key)
+		if !ok {
+			return nil, false
+		}
+		value, ok := constString(pkg, kv.Value)
+		if !ok {
+			return nil, false
+		}
+		names[key] = value
+	}
+	return names, true
+}
+
+// varValue returns the expression a package level variable is initialized
+// with.
+func varValue(pkg *packages.Package, obj *types.Var) ast.Expr {
+	for _, file := range pkg.Syntax {
+		for _, d := range file.Decls {
+			gen, ok := d.(*ast.GenDecl)
+			if !ok {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				vs, ok := spec.(*ast.ValueSpec)
+				if !ok {
+					continue
+				}
+				for i, name := range vs.Names {
+					if pkg.TypesInfo.Defs[name] == obj && i < len(vs.Values) {
+						return vs.Values[i]
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func constInt(pkg *packages.Package, expr ast.Expr) (int64, bool) {
+	tv, ok := pkg.TypesInfo.Types[expr]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.Int {
+		return 0, false
+	}
+	return constant.Int64Val(tv.Value)
+}
+
+// constString evaluates a constant string, or a constant slice of a constant
+// string. Stringer generates expressions like '_Foo_name[0:3]'.
+func constString(pkg *packages.Package, expr ast.Expr) (string, bool) {
+	if tv, ok := pkg.TypesInfo.Types[expr]; ok && tv.Value != nil && tv.Value.Kind() == constant.String {
+		return constant.StringVal(tv.Value), true
+	}
+
+	slice, ok := expr.(*ast.SliceExpr)
+	if !ok || slice.Slice3 {
+		return "", false
+	}
+	str, ok := constString(pkg, slice.X)
+	if !ok {
+		return "", false
+	}
+
+	low, high := int64(0), int64(len(str))
+	if slice.Low != nil {
+		if low, ok = constInt(pkg, slice.Low); !ok {
+			return "", false
+		}
+	}
+	if slice.High != nil {
+		if high, ok = constInt(pkg, slice.High); !ok {
+			return "", false
+		}
+	}
+	if low < 0 || high > int64(len(str)) || low > high {
+		return "", false
+	}
+	return str[low:high], true
+}
+
+// splitIntoRuns matches how stringer splits sorted values into runs of
+// consecutive values.
+func splitIntoRuns(values []int64) [][]int64 {
+	var runs [][]int64
+	for i := 0; i < len(values); {
+		j := i + 1
+		for j < len(values) && values[j] == values[j-1]+1 {
+			j++
+		}
+		runs = append(runs, values[i:j])
+		i = j
+	}
+	return runs
+}
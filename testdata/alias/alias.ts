@@ -39,8 +39,8 @@ export interface FooStruct {
 // From alias/alias.go
 export interface GenericUseRemappedAlias {
     readonly Field: string;
-    readonly AsKey: Record<string, string> | null;
-    readonly AsVal: Record<string, string> | null;
+    readonly AsKey: Readonly<Record<string, string>> | null;
+    readonly AsVal: Readonly<Record<string, string>> | null;
     readonly AsSlice: readonly string[];
     readonly AsGeneric: string;
 }
@@ -51,8 +51,8 @@ export type RemappedAlias = string;
 // From alias/alias.go
 export interface UseAliasedType<G extends any> {
     readonly Field: string;
-    readonly AsKey: Record<string, string> | null;
-    readonly AsVal: Record<string, string> | null;
+    readonly AsKey: Readonly<Record<string, string>> | null;
+    readonly AsVal: Readonly<Record<string, string>> | null;
     readonly AsSlice: readonly string[];
     readonly AsGeneric: G;
 }
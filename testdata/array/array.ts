@@ -2,7 +2,7 @@
 
 // From array/array.go
 export interface StaticArray {
-    readonly Numbers: [
+    readonly Numbers: readonly [
         number,
         number,
         number
@@ -0,0 +1,64 @@
+package branded
+
+import (
+	"net/netip"
+	"time"
+)
+
+// UserID is the unique identifier of a user.
+type UserID string
+
+type WorkspaceID string
+
+type Count int
+
+type Enabled bool
+
+// Status is an enum, enums are not branded.
+type Status string
+
+const (
+	StatusActive   Status = "active"
+	StatusInactive Status = "inactive"
+)
+
+// Alias is the same type as a string, so it is not branded.
+type Alias = string
+
+// NodeAddr is defined from a type with an override, but the methods of
+// netip.Addr are not inherited, so it marshals as the struct.
+type NodeAddr netip.Addr
+
+type Created time.Time
+
+// Deadline marshals like time.Time, so it uses the override.
+type Deadline time.Time
+
+func (d Deadline) MarshalText() ([]byte, error) {
+	return time.Time(d).MarshalText()
+}
+
+// ListenAddr marshals like netip.Addr, so it uses the override.
+type ListenAddr netip.Addr
+
+func (a ListenAddr) MarshalText() ([]byte, error) {
+	return netip.Addr(a).MarshalText()
+}
+
+type Tags []string
+
+type User struct {
+	ID        UserID       `json:"id"`
+	Workspace WorkspaceID  `json:"workspace"`
+	Status    Status       `json:"status"`
+	Addr      NodeAddr     `json:"addr"`
+	Prefix    netip.Prefix `json:"prefix"`
+	Created   Created      `json:"created"`
+	Deadline  Deadline     `json:"deadline"`
+	Listen    ListenAddr   `json:"listen"`
+	Updated   time.Time    `json:"updated"`
+	Count     Count        `json:"count"`
+	Enabled   Enabled      `json:"enabled"`
+	Alias     Alias        `json:"alias"`
+	Tags      Tags         `json:"tags"`
+}
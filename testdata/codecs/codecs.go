@@ -0,0 +1,41 @@
+package codecs
+
+import "time"
+
+// Audit is embedded in other types.
+type Audit struct {
+	CreatedAt time.Time  `json:"created_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+type User struct {
+	Audit
+	ID    int64  `json:"id"`
+	Name  string `json:"name"`
+	Count int32  `json:"count"`
+}
+
+type Event struct {
+	At       time.Time            `json:"at"`
+	Seen     []time.Time          `json:"seen"`
+	ByName   map[string]time.Time `json:"by_name"`
+	Owner    *User                `json:"owner,omitempty"`
+	Users    []User               `json:"users"`
+	Schedule Schedule             `json:"schedule"`
+	Labels   map[string]string    `json:"labels"`
+}
+
+// Schedule is a list of times.
+type Schedule []time.Time
+
+// Page is generic, so it is not converted. Fields of a generic type with a
+// rich type are an error, see the 'generic' package.
+type Page[T any] struct {
+	Items []T       `json:"items"`
+	At    time.Time `json:"at"`
+}
+
+// Plain has nothing to convert.
+type Plain struct {
+	Name string `json:"name"`
+}
@@ -0,0 +1,23 @@
+package generic
+
+import "time"
+
+type Page[T any] struct {
+	Items []T `json:"items"`
+}
+
+type Stamped[T any] struct {
+	Value T         `json:"value"`
+	At    time.Time `json:"at"`
+}
+
+type Plain struct {
+	Name string `json:"name"`
+}
+
+type Feed struct {
+	At      time.Time       `json:"at"`
+	Events  Page[time.Time] `json:"events"`
+	Stamped Stamped[Plain]  `json:"stamped"`
+	Plain   Page[Plain]     `json:"plain"`
+}
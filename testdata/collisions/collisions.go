@@ -0,0 +1,31 @@
+package collisions
+
+import "github.com/coder/guts/testdata/collisions/other"
+
+type User struct {
+	Name  string     `json:"name"`
+	Other other.User `json:"other"`
+}
+
+type Status string
+
+const (
+	StatusActive Status = "active"
+)
+
+type Unique struct {
+	Status       Status       `json:"status"`
+	OtherStatus  other.Status `json:"other_status"`
+	OtherRenamed other.Rename `json:"other_renamed"`
+}
+
+type Rename string
+
+type Kind string
+
+const (
+	KindB Kind = "b"
+)
+
+// DefaultUser collides with the generated variable of the other package.
+var DefaultUser = User{Name: "default"}
@@ -0,0 +1,15 @@
+package other
+
+type User struct {
+	ID int `json:"id"`
+}
+
+type Status string
+
+const (
+	StatusPending Status = "pending"
+)
+
+type Rename string
+
+var DefaultUser = User{ID: 1}
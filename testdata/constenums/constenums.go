@@ -0,0 +1,22 @@
+package constenums
+
+// Color is a string-based enum
+type Color string
+
+const (
+	// ColorRed is the "red" value
+	ColorRed   Color = "red"
+	ColorGreen Color = "green"
+)
+
+type Priority int
+
+const (
+	PriorityLow  Priority = 1
+	PriorityHigh Priority = 10
+)
+
+type Paint struct {
+	Color    Color    `json:"color"`
+	Priority Priority `json:"priority"`
+}
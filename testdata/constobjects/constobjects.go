@@ -0,0 +1,35 @@
+package constobjects
+
+// Color is a string-based enum
+type Color string
+
+const (
+	// ColorRed is the "red" value
+	ColorRed   Color = "red"
+	ColorGreen Color = "green"
+)
+
+type Priority int
+
+const (
+	PriorityLow  Priority = 1
+	PriorityHigh Priority = 10
+)
+
+// Match and Matche both list as 'Matches', so neither list is added.
+type Match string
+
+const (
+	MatchExact Match = "exact"
+)
+
+type Matche string
+
+const (
+	MatcheFuzzy Matche = "fuzzy"
+)
+
+type Paint struct {
+	Color    Color    `json:"color"`
+	Priority Priority `json:"priority"`
+}
@@ -0,0 +1,20 @@
+package constraints
+
+type CreateUserRequest struct {
+	// Name is the display name of the user.
+	Name     string   `json:"name" validate:"required,min=1,max=64"`
+	Email    string   `json:"email,omitempty" validate:"required,email"`
+	Username string   `json:"username" validate:"startswith=u_"`
+	Role     string   `json:"role" validate:"oneof=admin member 'read only'"`
+	Age      *int     `json:"age,omitempty" validate:"omitempty,gte=18,lt=150"`
+	Score    float64  `json:"score" binding:"gt=0,lte=1"`
+	Level    int      `json:"level" validate:"oneof=1 2 3"`
+	Tags     []string `json:"tags" validate:"max=10,dive,min=1"`
+	Code     string   `json:"code" validate:"len=6,numeric"`
+	// Website is either a url or an email.
+	Website string `json:"website,omitempty" validate:"url|email"`
+	// Directory would end the JSDoc comment if the pattern was not escaped.
+	Directory string `json:"directory" validate:"endswith=*/"`
+	// Referrer is only validated by a cross field rule.
+	Referrer string `json:"referrer,omitempty" validate:"required_with=Name"`
+}
@@ -2,21 +2,21 @@
 
 // From enums/enums.go
 export enum Audience {
+    World = "world",
+    Tenant = "tenant",
     /**
      * AudienceTeam is the "team" value
      */
-    Team = "team",
-    Tenant = "tenant",
-    World = "world"
+    Team = "team"
 }
 
 // From enums/enums.go
 export enum EnumInt {
-    EnumNumBar = 10,
     /**
      * EnumNumFoo is the number 5
      */
-    EnumNumFoo = 5
+    EnumNumFoo = 5,
+    EnumNumBar = 10
 }
 
 // From enums/enums.go
@@ -30,15 +30,15 @@ export type EnumSliceType = readonly EnumString[];
  * EnumString is a string-based enum
  */
 export enum EnumString {
-    /**
-     * EnumBar is the "bar" value
-     */
-    EnumBar = "bar",
-    EnumBaz = "baz",
     /**
      * EnumFoo is the "foo" value
      * This comment should be preserved
      */
     EnumFoo = "foo",
+    /**
+     * EnumBar is the "bar" value
+     */
+    EnumBar = "bar",
+    EnumBaz = "baz",
     EnumQux = "qux"
 }
@@ -1,27 +1,27 @@
 // Code generated by 'guts'. DO NOT EDIT.
 
 // From enumtypes/enumtypes.go
-export type Audience = "team" | "tenant" | "world";
+export type Audience = "world" | "tenant" | "team";
 
-export const Audiences: Audience[] = ["team", "tenant", "world"];
+export const Audiences: Audience[] = ["world", "tenant", "team"];
 
 // From enumtypes/enumtypes.go
-export type EnumAlias = "bool" | "list(string)" | "number" | "string";
+export type EnumAlias = "string" | "number" | "bool" | "list(string)";
 
-export const EnumAliases: EnumAlias[] = ["bool", "list(string)", "number", "string"];
+export const EnumAliases: EnumAlias[] = ["string", "number", "bool", "list(string)"];
 
 // From enumtypes/enumtypes.go
-export type EnumInt = 10 | 5;
+export type EnumInt = 5 | 10;
 
-export const EnumInts: EnumInt[] = [10, 5];
+export const EnumInts: EnumInt[] = [5, 10];
 
 // From enumtypes/enumtypes.go
 export type EnumSliceType = readonly EnumString[];
 
 // From enumtypes/enumtypes.go
-export type EnumString = "bar" | "baz" | "foo" | "qux";
+export type EnumString = "foo" | "bar" | "baz" | "qux";
 
-export const EnumStrings: EnumString[] = ["bar", "baz", "foo", "qux"];
+export const EnumStrings: EnumString[] = ["foo", "bar", "baz", "qux"];
 
 export const Policies: Policy[] = ["allow", "deny"];
 
@@ -0,0 +1,20 @@
+package fieldoverrides
+
+import "encoding/json"
+
+type Workspace struct {
+	Name string `json:"name"`
+	// Settings is a known schema.
+	Settings json.RawMessage `json:"settings"`
+	Owner    *string         `json:"owner"`
+	Deleted  bool            `json:"deleted,omitempty"`
+	Legacy   string          `json:"legacy_name"`
+	Other    json.RawMessage `json:"other"`
+	Count    int64           `json:"count,string"`
+	Secret   string          `json:"-"`
+	internal string
+}
+
+type WorkspaceSettings struct {
+	Theme string `json:"theme"`
+}
@@ -0,0 +1,20 @@
+package formats
+
+import (
+	"net/netip"
+	"time"
+)
+
+type NodeAddr netip.Addr
+
+func (a NodeAddr) MarshalText() ([]byte, error) {
+	return netip.Addr(a).MarshalText()
+}
+
+type Server struct {
+	Addr     netip.Addr     `json:"addr"`
+	AddrPort netip.AddrPort `json:"addr_port"`
+	Node     NodeAddr       `json:"node"`
+	Started  time.Time      `json:"started"`
+	Stopped  *time.Time     `json:"stopped"`
+}
@@ -0,0 +1,22 @@
+package functypes
+
+import "context"
+
+type User struct {
+	Name string `json:"name"`
+}
+
+// Lookup finds a user by name.
+type Lookup func(ctx context.Context, name string) (User, error)
+
+type Visit func(users ...User)
+
+type Pair func(string, int) (int, bool)
+
+type Mapper[T any, R any] func(value T) R
+
+type Handlers struct {
+	OnChange func(old, new User)    `json:"on_change"`
+	Load     func() ([]byte, error) `json:"load"`
+	Lookup   Lookup                 `json:"lookup"`
+}
@@ -26,5 +26,5 @@ export interface FooBuzzAnonymousUnion<R extends Foo | Buzz> {
 
 // From codersdk/genericmap.go
 export interface FooBuzzMap<R extends Custom> {
-    readonly something: Record<string, R> | null;
+    readonly something: Readonly<Record<string, R>> | null;
 }
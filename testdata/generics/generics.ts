@@ -22,7 +22,7 @@ export interface Complex<C extends Comparable, S extends Single, T extends Custo
 }
 
 // From codersdk/generics.go
-export type Custom = string | boolean | number | number | string[] | (number | null);
+export type Custom = string | boolean | number | number | readonly string[] | (number | null);
 
 // From codersdk/generics.go
 /**
@@ -8,5 +8,5 @@ export interface Bar {
 // From codersdk/genericslice.go
 export interface Foo<R extends any> {
     readonly Slice: readonly R[];
-    readonly TwoD: readonly R[][];
+    readonly TwoD: readonly (readonly R[])[];
 }
@@ -0,0 +1,29 @@
+package int64policy
+
+// ID uses the string policy, set per type.
+type ID int64
+
+// Counter uses the global bigint policy.
+type Counter uint64
+
+const (
+	CounterZero Counter = 0
+	CounterHuge Counter = 1<<63 + 5
+)
+
+type Stats struct {
+	ID        ID      `json:"id"`
+	Count     int64   `json:"count"`
+	Size      int     `json:"size"`
+	Small     int32   `json:"small"`
+	Ratio     float64 `json:"ratio"`
+	Quoted    int64   `json:"quoted,string"`
+	QuotedPtr *bool   `json:"quoted_ptr,string"`
+	Counter   Counter `json:"counter"`
+}
+
+var DefaultStats = Stats{
+	ID:     9007199254740993,
+	Count:  -5,
+	Quoted: 10,
+}
@@ -8,11 +8,11 @@ export type Address = {
     /**
      * Street address
      */
-    street: string;
+    readonly street: string;
     /**
      * City name
      */
-    city: string;
+    readonly city: string;
 };
 
 // From codersdk/interfacetotype-comments.go
@@ -24,7 +24,7 @@ export type Profile = User & Address & {
     /**
      * Bio is the user's biography
      */
-    bio: string;
+    readonly bio: string;
 };
 
 // From codersdk/interfacetotype-comments.go
@@ -36,13 +36,13 @@ export type User = {
     /**
      * ID is the unique identifier
      */
-    id: string;
+    readonly id: string;
     /**
      * Name is the user's full name
      */
-    name: string;
+    readonly name: string;
     /**
      * Email is the user's email address
      */
-    email: string;
+    readonly email: string;
 };
@@ -2,35 +2,35 @@
 
 // From codersdk/interfacetotype.go
 export type Address = {
-    street: string;
-    city: string;
-    country: string;
+    readonly street: string;
+    readonly city: string;
+    readonly country: string;
 };
 
 export type Comparable = string | number | boolean;
 
 // From codersdk/interfacetotype.go
 export type GenericContainer<T extends any> = {
-    value: T;
-    count: number;
+    readonly value: T;
+    readonly count: number;
 };
 
 // From codersdk/interfacetotype.go
 export type Player<ID extends Comparable, P extends number> = User<ID> & Score<P> & {
-    x: number;
-    y: number;
+    readonly x: number;
+    readonly y: number;
 };
 
 // From codersdk/interfacetotype.go
 export type Score<T extends number> = {
-    points: T;
-    level: number;
+    readonly points: T;
+    readonly level: number;
 };
 
 // From codersdk/interfacetotype.go
 export type User<T extends Comparable> = {
-    id: T;
-    name: string;
-    email: string;
-    is_active: boolean;
+    readonly id: T;
+    readonly name: string;
+    readonly email: string;
+    readonly is_active: boolean;
 };
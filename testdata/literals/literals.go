@@ -0,0 +1,25 @@
+package literals
+
+import "math"
+
+const (
+	Negative      = -42
+	NegativeFloat = -0.5
+	MinInt64      = math.MinInt64
+	MaxUint64     = math.MaxUint64
+)
+
+type Direction int
+
+const (
+	Backward Direction = -1
+	Still    Direction = 0
+	Forward  Direction = 1
+)
+
+type Offset struct {
+	X int     `json:"x"`
+	Y float64 `json:"y"`
+}
+
+var Origin = Offset{X: -1, Y: -2.5}
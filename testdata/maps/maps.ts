@@ -2,7 +2,7 @@
 
 // From maps/map.go
 export interface Bar<T extends any> {
-    readonly SimpleMap: Record<string, string> | null;
-    readonly NumberMap: Record<string, number> | null;
-    readonly GenericMap: Record<string, T> | null;
+    readonly SimpleMap: Readonly<Record<string, string>> | null;
+    readonly NumberMap: Readonly<Record<string, number>> | null;
+    readonly GenericMap: Readonly<Record<string, T>> | null;
 }
@@ -0,0 +1,36 @@
+package methods
+
+type Value struct {
+	Data string `json:"data"`
+}
+
+type Getter interface {
+	// Get returns the value for the key.
+	Get(key string) (Value, error)
+}
+
+// Store is a key value store exposed to javascript.
+type Store interface {
+	Getter
+	error
+
+	Put(key string, v Value) error
+	PutAll(values []Value, tags map[string]string) error
+	Keys(prefix string, limit int) []string
+	Delete(keys ...string) (int, bool)
+	close()
+}
+
+type Cache[T any] interface {
+	Lookup(key string) (T, bool)
+}
+
+type Handler struct {
+	Store Store         `json:"store"`
+	Cache Cache[string] `json:"cache"`
+}
+
+// Constraint is a type constraint, not a method set.
+type Constraint interface {
+	~string | ~int
+}
@@ -0,0 +1,17 @@
+package account
+
+type User struct {
+	ID   string `json:"id"`
+	Role Role   `json:"role"`
+}
+
+type Member struct {
+	User User `json:"user"`
+}
+
+type Role string
+
+const (
+	RoleOwner  Role = "owner"
+	RoleMember Role = "member"
+)
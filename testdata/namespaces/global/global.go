@@ -0,0 +1,7 @@
+// Package global would be the 'declare global' block if the namespace was
+// the package name.
+package global
+
+type Settings struct {
+	Theme string `json:"theme"`
+}
@@ -0,0 +1,7 @@
+// Package account has the same name as 'namespaces/account', so the
+// namespaces use more of the package path.
+package account
+
+type User struct {
+	Email string `json:"email"`
+}
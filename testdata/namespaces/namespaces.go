@@ -0,0 +1,28 @@
+package namespaces
+
+import (
+	"github.com/coder/guts/testdata/namespaces/account"
+	"github.com/coder/guts/testdata/namespaces/global"
+	legacy "github.com/coder/guts/testdata/namespaces/legacy/account"
+)
+
+// User collides with 'account.User', which is fine in separate namespaces.
+type User struct {
+	Name     string          `json:"name"`
+	Account  account.User    `json:"account"`
+	Role     account.Role    `json:"role"`
+	Roles    []account.Role  `json:"roles"`
+	Meta     Meta[string]    `json:"meta"`
+	Owner    *account.Member `json:"owner"`
+	Legacy   legacy.User     `json:"legacy"`
+	Settings global.Settings `json:"settings"`
+}
+
+type Meta[T comparable] struct {
+	Value T `json:"value"`
+}
+
+type Admin struct {
+	account.Member
+	Level int `json:"level"`
+}
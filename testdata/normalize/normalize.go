@@ -0,0 +1,30 @@
+package normalize
+
+// Duplicates has a constraint that generates duplicate union members.
+type Duplicates interface {
+	string | int | int32 | ~string
+}
+
+type AnyOrString interface {
+	any | string
+}
+
+type Generic[T Duplicates, A AnyOrString] struct {
+	Value T `json:"value"`
+	Any   A `json:"any"`
+}
+
+type Normalize struct {
+	DoublePointer         **string             `json:"double_pointer"`
+	OptionalPointer       *string              `json:"optional_pointer,omitempty"`
+	OptionalDoublePointer **int                `json:"optional_double_pointer,omitempty"`
+	OptionalMap           map[string]string    `json:"optional_map,omitempty"`
+	PointerSlice          []**string           `json:"pointer_slice"`
+	PointerMap            *map[string]**string `json:"pointer_map"`
+	// A non-nil pointer to a nil value is null, and not omitted.
+	OptionalPointerSlice *[]string          `json:"optional_pointer_slice,omitempty"`
+	OptionalPointerMap   *map[string]string `json:"optional_pointer_map,omitempty"`
+	OptionalPointerAny   *any               `json:"optional_pointer_any,omitempty"`
+	OptionalAny          any                `json:"optional_any,omitempty"`
+	OptionalSlice        []string           `json:"optional_slice,omitempty"`
+}
@@ -14,5 +14,5 @@ export interface NullableFields {
     readonly nullableOmitEmpty?: string | null;
     readonly nullableOmitZero?: string | null;
     readonly nullTime: string | null;
-    readonly slicePointer: readonly (string)[];
+    readonly slicePointer: readonly string[];
 }
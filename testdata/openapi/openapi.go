@@ -0,0 +1,52 @@
+package openapi
+
+import "time"
+
+// Role is the permission level of a user.
+type Role string
+
+const (
+	RoleAdmin  Role = "admin"
+	RoleMember Role = "member"
+)
+
+type Base struct {
+	ID        string    `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// User is a person that can log in.
+type User struct {
+	Base
+	// Name is the display name.
+	Name  string  `json:"name" validate:"required,min=1,max=64"`
+	Email *string `json:"email" validate:"omitempty,email"`
+	Roles []Role  `json:"roles"`
+	// Deprecated: Use Roles instead.
+	Admin  bool              `json:"admin,omitempty"`
+	Labels map[string]string `json:"labels"`
+	Score  [2]float64        `json:"score"`
+}
+
+type Group struct {
+	Name    string `json:"name" validate:"alphanum"`
+	Members []User `json:"members" validate:"max=100"`
+}
+
+// Page is a single page of results.
+type Page[T any] struct {
+	Items []T   `json:"items"`
+	Next  *T    `json:"next"`
+	Total int64 `json:"total"`
+}
+
+type Response struct {
+	Users  Page[User]        `json:"users"`
+	Groups Page[Group]       `json:"groups"`
+	Nested Page[Page[Group]] `json:"nested"`
+}
+
+// Unused generics are not generated.
+type Unused[T comparable] struct {
+	Value T `json:"value"`
+}
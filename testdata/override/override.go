@@ -9,3 +9,16 @@ type OverrideTypes struct {
 	Reg *regexp.Regexp
 	U   *url.URL
 }
+
+type Pixels int
+
+type Created string
+
+type UserID string
+
+type Custom struct {
+	Width   Pixels   `json:"width"`
+	Created Created  `json:"created"`
+	Owner   UserID   `json:"owner"`
+	Members []UserID `json:"members"`
+}
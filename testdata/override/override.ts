@@ -1,7 +1,24 @@
 // Code generated by 'guts'. DO NOT EDIT.
 
+// From override/override.go
+export type Created = Date | null;
+
+// From override/override.go
+export interface Custom {
+    readonly width: `${number}px`;
+    readonly created: Date | null;
+    readonly owner: Brand<string, "UserID">;
+    readonly members: readonly Brand<string, "UserID">[];
+}
+
 // From override/override.go
 export interface OverrideTypes {
     readonly Reg: string | null;
     readonly U: string | null;
 }
+
+// From override/override.go
+export type Pixels = `${number}px`;
+
+// From override/override.go
+export type UserID = Brand<string, "UserID">;
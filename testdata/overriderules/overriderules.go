@@ -0,0 +1,60 @@
+package overriderules
+
+import (
+	"database/sql"
+	"encoding/json"
+)
+
+// Optional is overridden by a regex, with its type parameter.
+type Optional[T any] struct {
+	Value T
+	Set   bool
+}
+
+// DBID is overridden by a glob.
+type DBID [16]byte
+
+// DBStatus matches the glob before it matches the stringer predicate.
+type DBStatus int
+
+func (DBStatus) String() string { return "" }
+
+// Level is overridden by the stringer predicate.
+type Level int
+
+func (Level) String() string { return "" }
+
+type Record struct {
+	ID       DBID             `json:"id"`
+	Status   DBStatus         `json:"status"`
+	Level    Level            `json:"level"`
+	Name     sql.Null[string] `json:"name"`
+	Count    sql.Null[int]    `json:"count"`
+	Nickname Optional[string] `json:"nickname"`
+}
+
+type Page[T any] struct {
+	Next  sql.Null[T]   `json:"next"`
+	Items []Optional[T] `json:"items"`
+}
+
+// NullName is defined from an instantiation matched by a generic rule.
+type NullName sql.Null[string]
+
+func (n NullName) MarshalJSON() ([]byte, error) {
+	return marshalNull(n.V, n.Valid)
+}
+
+// NullOf is defined from a generic instantiation of its own parameter.
+type NullOf[T any] sql.Null[T]
+
+func (n NullOf[T]) MarshalJSON() ([]byte, error) {
+	return marshalNull(n.V, n.Valid)
+}
+
+func marshalNull(v any, valid bool) ([]byte, error) {
+	if !valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(v)
+}
@@ -0,0 +1,87 @@
+// Package protobuf mimics the output of 'protoc-gen-go', without depending on
+// the protobuf runtime.
+package protobuf
+
+type (
+	messageState  struct{}
+	sizeCache     int32
+	unknownFields []byte
+)
+
+type Status int32
+
+const (
+	Status_STATUS_UNSPECIFIED Status = 0
+	Status_STATUS_ACTIVE      Status = 1
+	Status_STATUS_DISABLED    Status = 2
+)
+
+// Enum value maps for Status.
+var (
+	Status_name = map[int32]string{
+		0: "STATUS_UNSPECIFIED",
+		1: "STATUS_ACTIVE",
+		2: "STATUS_DISABLED",
+	}
+	Status_value = map[string]int32{
+		"STATUS_UNSPECIFIED": 0,
+		"STATUS_ACTIVE":      1,
+		"STATUS_DISABLED":    2,
+	}
+)
+
+func (x Status) Enum() *Status {
+	p := new(Status)
+	*p = x
+	return p
+}
+
+func (x Status) String() string {
+	return Status_name[int32(x)]
+}
+
+type User struct {
+	state         messageState
+	sizeCache     sizeCache
+	unknownFields unknownFields
+
+	Id          int64             `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	DisplayName string            `protobuf:"bytes,2,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	Status      Status            `protobuf:"varint,3,opt,name=status,proto3,enum=example.Status" json:"status,omitempty"`
+	Nickname    *string           `protobuf:"bytes,4,opt,name=nickname,proto3,oneof" json:"nickname,omitempty"`
+	Groups      []*Group          `protobuf:"bytes,5,rep,name=groups,proto3" json:"groups,omitempty"`
+	Labels      map[string]string `protobuf:"bytes,6,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Manager     *User             `protobuf:"bytes,7,opt,name=manager,proto3" json:"manager,omitempty"`
+	// Types that are valid to be assigned to Contact:
+	//
+	//	*User_Email
+	//	*User_Phone
+	Contact isUser_Contact `protobuf_oneof:"contact"`
+	Avatar  []byte         `protobuf:"bytes,10,opt,name=avatar,proto3" json:"avatar,omitempty"`
+}
+
+type isUser_Contact interface {
+	isUser_Contact()
+}
+
+type User_Phone struct {
+	Phone string `protobuf:"bytes,9,opt,name=phone,proto3,oneof"`
+}
+
+type User_Email struct {
+	Email string `protobuf:"bytes,8,opt,name=email,proto3,oneof"`
+}
+
+func (*User_Email) isUser_Contact() {}
+
+func (*User_Phone) isUser_Contact() {}
+
+type Group struct {
+	state         messageState
+	sizeCache     sizeCache
+	unknownFields unknownFields
+
+	// Name is the unique name of the group.
+	Name        string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	MemberCount uint64 `protobuf:"varint,2,opt,name=member_count,json=memberCount,proto3" json:"member_count,omitempty"`
+}
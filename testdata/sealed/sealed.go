@@ -0,0 +1,105 @@
+package sealed
+
+import "time"
+
+const TypeDeleted = "deleted"
+
+// Event is a change to a resource.
+//
+//guts:union type
+type Event interface {
+	isEvent()
+}
+
+type CreatedEvent struct {
+	Type    string    `json:"type"`
+	Name    string    `json:"name"`
+	Created time.Time `json:"created"`
+}
+
+func (CreatedEvent) isEvent() {}
+
+type DeletedEvent struct {
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Reason  string `json:"reason,omitempty"`
+	Deleted bool   `json:"deleted"`
+}
+
+func (*DeletedEvent) isEvent() {}
+
+type Notification struct {
+	Event  Event   `json:"event"`
+	Events []Event `json:"events"`
+}
+
+func NewCreated(name string) Event {
+	return CreatedEvent{Type: "created", Name: name, Created: time.Now()}
+}
+
+func NewDeleted(name string) Event {
+	return &DeletedEvent{Type: TypeDeleted, Name: name, Deleted: true}
+}
+
+// UpdatedEvent is only built by assignment, so the value is annotated.
+//
+//guts:discriminator "updated"
+type UpdatedEvent struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+func (UpdatedEvent) isEvent() {}
+
+func NewUpdated(name string) Event {
+	var e UpdatedEvent
+	e.Type = "updated"
+	e.Name = name
+	return e
+}
+
+type EventType string
+
+// RenamedEventType is the discriminator value of RenamedEvent.
+const RenamedEventType EventType = "renamed"
+
+type RenamedEvent struct {
+	Type EventType `json:"type"`
+	From string    `json:"from"`
+}
+
+func (RenamedEvent) isEvent() {}
+
+// UnknownEvent is never constructed, so the discriminator is a string.
+type UnknownEvent struct {
+	Type string `json:"type"`
+}
+
+func (UnknownEvent) isEvent() {}
+
+// Base is embedded by variants, and the discriminator is promoted from it.
+type Base struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+type ArchivedEvent struct {
+	Base
+	Archived bool `json:"archived"`
+}
+
+func (ArchivedEvent) isEvent() {}
+
+func NewArchived(id string) Event {
+	return ArchivedEvent{Base: Base{Type: "archived", ID: id}, Archived: true}
+}
+
+// RestoredEventType is the discriminator value of RestoredEvent.
+const RestoredEventType string = "restored"
+
+type RestoredEvent struct {
+	*Base
+	Restored bool `json:"restored"`
+}
+
+func (RestoredEvent) isEvent() {}
@@ -0,0 +1,134 @@
+package stringerenums
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+//go:generate stringer -type=Color,Code -output=stringerenums_string.go
+
+// Color is marshaled as text using the stringer generated names.
+type Color int
+
+const (
+	ColorRed Color = iota
+	ColorGreen
+	ColorBlue
+)
+
+func (c Color) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// Code has multiple runs of values.
+type Code int
+
+const (
+	CodeOK       Code = 1
+	CodeRetry    Code = 2
+	CodeNotFound Code = 10
+	CodeGone     Code = 11
+)
+
+func (c Code) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// Shape implements String by hand.
+type Shape int
+
+const (
+	ShapeCircle Shape = iota
+	ShapeSquare
+)
+
+func (s Shape) String() string {
+	switch s {
+	case ShapeCircle:
+		return "circle"
+	case ShapeSquare:
+		return "square"
+	}
+	return "unknown"
+}
+
+func (s Shape) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// Weekday uses a lookup table for its names.
+type Weekday int
+
+const (
+	Monday Weekday = iota + 1
+	Tuesday
+)
+
+var weekdayNames = map[Weekday]string{
+	Monday:  "mon",
+	Tuesday: "tue",
+}
+
+// Weekday only implements String, which is used when
+// 'GoParser.StringerEnums' is enabled.
+func (d Weekday) String() string {
+	return weekdayNames[d]
+}
+
+// Level is marshaled as a number.
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelError
+)
+
+// Priority is marshaled as text, but the names cannot be determined. It is
+// generated as a numeric enum.
+type Priority int
+
+const (
+	PriorityLow Priority = iota
+	PriorityHigh
+)
+
+func (p Priority) MarshalText() ([]byte, error) {
+	return []byte("p" + strconv.Itoa(int(p))), nil
+}
+
+// Mode implements MarshalJSON, which takes precedence over MarshalText.
+type Mode int
+
+const (
+	ModeRead Mode = iota
+	ModeWrite
+)
+
+func (m Mode) String() string {
+	switch m {
+	case ModeRead:
+		return "read"
+	case ModeWrite:
+		return "write"
+	}
+	return "unknown"
+}
+
+func (m Mode) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+func (m Mode) MarshalJSON() ([]byte, error) {
+	return json.Marshal(int(m))
+}
+
+type Log struct {
+	Color    Color    `json:"color"`
+	Code     Code     `json:"code"`
+	Shape    Shape    `json:"shape"`
+	Weekday  Weekday  `json:"weekday"`
+	Level    Level    `json:"level"`
+	Priority Priority `json:"priority"`
+	Mode     Mode     `json:"mode"`
+}
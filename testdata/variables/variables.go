@@ -0,0 +1,63 @@
+package variables
+
+import "errors"
+
+type Role string
+
+const (
+	RoleAdmin  Role = "admin"
+	RoleMember Role = "member"
+)
+
+type Permission struct {
+	Resource string `json:"resource"`
+	Action   string `json:"action"`
+	Allowed  bool   `json:"allowed"`
+}
+
+type Base struct {
+	Version int `json:"version"`
+}
+
+type Config struct {
+	Base
+	Name     string            `json:"name"`
+	Timeout  float64           `json:"timeout"`
+	Retries  int               `json:"retries"`
+	Tags     []string          `json:"tags"`
+	Labels   map[string]string `json:"labels"`
+	Optional string            `json:"optional,omitempty"`
+	Ignored  string            `json:"-"`
+	Nested   *Permission       `json:"nested"`
+	internal string
+}
+
+// DefaultConfig is the config used when none is provided.
+var DefaultConfig = Config{
+	Base:    Base{Version: 2},
+	Name:    "default",
+	Timeout: 1.5,
+	Tags:    []string{"a", "b"},
+	Nested:  &Permission{"workspace", "read", true},
+}
+
+var FeatureFlags = []string{"one", "two"}
+
+var RolePermissions = map[Role][]Permission{
+	RoleAdmin: {
+		{Resource: "workspace", Action: "delete", Allowed: true},
+	},
+	RoleMember: {
+		{Resource: "workspace", Action: "delete"},
+	},
+}
+
+var Limits = map[string]int{
+	"users":      100,
+	"workspaces": 10,
+}
+
+// Runtime values are not generated.
+var ErrNotFound = errors.New("not found")
+
+var unexported = []string{"hidden"}
@@ -0,0 +1,44 @@
+//go:build js && wasm
+
+package wasm
+
+import "syscall/js"
+
+type User struct {
+	Name  string `json:"name"`
+	Admin bool   `json:"admin"`
+}
+
+type Filter struct {
+	Prefix string `json:"prefix"`
+	Limit  int    `json:"limit"`
+}
+
+func Register() {
+	// listUsers returns all users matching the filter.
+	//guts:js func(filter Filter) ([]User, error)
+	js.Global().Set("listUsers", js.FuncOf(listUsers))
+
+	//guts:js func(names ...string) int
+	js.Global().Set("countUsers", js.FuncOf(countUsers))
+
+	js.Global().Set("unannotated", js.FuncOf(countUsers))
+
+	// Values are not functions.
+	js.Global().Set("version", js.ValueOf("1"))
+
+	// A function value is a registration.
+	released := js.FuncOf(countUsers)
+	js.Global().Set("released", released)
+
+	// Not a global registration.
+	js.Global().Get("console").Set("debug", js.FuncOf(countUsers))
+}
+
+func listUsers(this js.Value, args []js.Value) any {
+	return nil
+}
+
+func countUsers(this js.Value, args []js.Value) any {
+	return len(args)
+}
@@ -0,0 +1,15 @@
+//go:build js && wasm
+
+// Package wasmonly only registers functions, so the file has no exports.
+package wasmonly
+
+import "syscall/js"
+
+func Register() {
+	//guts:js func(a, b int) int
+	js.Global().Set("add", js.FuncOf(add))
+}
+
+func add(js.Value, []js.Value) any {
+	return nil
+}
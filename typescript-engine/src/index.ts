@@ -39,22 +39,22 @@ export function propertySignature(
   type: ts.TypeNode
 ): ts.PropertySignature {
 
-  var id: Identifier | StringLiteral = identifier(name);
+  return ts.factory.createPropertySignature(
+    modifiers?.map((m) => modifier(m)),
+    propertyName(name),
+    question ? questionToken() : undefined,
+    type
+  );
+}
 
+export function propertyName(name: string): Identifier | StringLiteral {
   // Property names can be quoted if they are not valid identifiers.
   // This is things like hyphens, numbers first, etc.
   // TODO: If this regex exists in the typescript compiler, use that instead.
   if(!(/^[A-Za-z_$][A-Za-z0-9_$]*$/.test(name))) {
-    id = ts.factory.createStringLiteral(name);
+    return ts.factory.createStringLiteral(name);
   }
-
-
-  return ts.factory.createPropertySignature(
-    modifiers?.map((m) => modifier(m)),
-    id,
-    question ? questionToken() : undefined,
-    type
-  );
+  return identifier(name);
 }
 
 export function questionToken(): ts.QuestionToken {
@@ -62,15 +62,26 @@ export function questionToken(): ts.QuestionToken {
 }
 
 export function reference(
-  name: string | ts.Identifier,
+  name: string | ts.EntityName,
   typeParameters: ts.TypeNode[]
 ): ts.TypeReferenceNode {
   return ts.factory.createTypeReferenceNode(
-    identifier(name),
+    typeof name === "string" ? identifier(name) : name,
     typeParameters // Type arguments, generics
   );
 }
 
+// qualifiedName references a name inside a namespace, eg: 'pkg.Type'
+export function qualifiedName(
+  left: string | ts.EntityName,
+  right: string | ts.Identifier
+): ts.QualifiedName {
+  return ts.factory.createQualifiedName(
+    typeof left === "string" ? identifier(left) : left,
+    identifier(right)
+  );
+}
+
 type keywordKeys = FilterKeys<typeof ts.SyntaxKind, ts.KeywordTypeSyntaxKind>;
 export function literalKeyword(keyword: keywordKeys): ts.KeywordTypeNode {
   return ts.factory.createKeywordTypeNode(ts.SyntaxKind[keyword]);
@@ -93,7 +104,11 @@ const printer = ts.createPrinter({
   removeComments: false,
 });
 
-export function toTypescript(node: ts.Node): string {
+export function toTypescript(node: ts.Node | ts.Node[]): string {
+  // Some declarations, like object enums, are multiple statements.
+  if (Array.isArray(node)) {
+    return node.map((n) => toTypescript(n)).join("\n");
+  }
   return printer.printNode(ts.EmitHint.Unspecified, node, resultFile);
 }
 
@@ -124,6 +139,48 @@ export function homogeneousTupleType(length: number, node: ts.TypeNode): ts.Tupl
   return ts.factory.createTupleTypeNode(nodes);
 }
 
+export function tupleType(elements: ts.TypeNode[]): ts.TupleTypeNode {
+  return ts.factory.createTupleTypeNode(elements);
+}
+
+export function functionType(
+  parameters: ts.ParameterDeclaration[],
+  type: ts.TypeNode
+): ts.FunctionTypeNode {
+  return ts.factory.createFunctionTypeNode(undefined, parameters, type);
+}
+
+export function parameter(
+  name: string,
+  type: ts.TypeNode | undefined,
+  rest: boolean,
+  question: boolean
+): ts.ParameterDeclaration {
+  return ts.factory.createParameterDeclaration(
+    undefined,
+    rest ? ts.factory.createToken(ts.SyntaxKind.DotDotDotToken) : undefined,
+    name,
+    question ? questionToken() : undefined,
+    type
+  );
+}
+
+export function methodSignature(
+  name: string,
+  question: boolean,
+  parameters: ts.ParameterDeclaration[],
+  type: ts.TypeNode
+): ts.MethodSignature {
+  return ts.factory.createMethodSignature(
+    undefined,
+    propertyName(name),
+    question ? questionToken() : undefined,
+    undefined,
+    parameters,
+    type
+  );
+}
+
 export function aliasDecl(
   modifiers: readonly modifierKeys[] | readonly ts.Modifier[] | undefined,
   name: ts.Identifier | string,
@@ -193,10 +250,44 @@ export function stringLiteral(value: string): ts.StringLiteral {
   return ts.factory.createStringLiteral(value);
 }
 
-export function numericLiteral(value: number): ts.NumericLiteral {
+export function numericLiteral(value: number | string): ts.NumericLiteral {
   return ts.factory.createNumericLiteral(value.toString());
 }
 
+export function bigIntLiteral(value: string): ts.BigIntLiteral {
+  return ts.factory.createBigIntLiteral(value + "n");
+}
+
+export function prefixUnaryExpression(
+  operator: "MinusToken" | "PlusToken" | "TildeToken" | "ExclamationToken",
+  operand: ts.Expression
+): ts.PrefixUnaryExpression {
+  return ts.factory.createPrefixUnaryExpression(ts.SyntaxKind[operator], operand);
+}
+
+export function templateLiteralType(
+  head: string,
+  spans: ts.TemplateLiteralTypeSpan[]
+): ts.TemplateLiteralTypeNode {
+  return ts.factory.createTemplateLiteralType(
+    ts.factory.createTemplateHead(head),
+    spans
+  );
+}
+
+export function templateLiteralTypeSpan(
+  type: ts.TypeNode,
+  literal: string,
+  tail: boolean
+): ts.TemplateLiteralTypeSpan {
+  return ts.factory.createTemplateLiteralTypeSpan(
+    type,
+    tail
+      ? ts.factory.createTemplateTail(literal)
+      : ts.factory.createTemplateMiddle(literal)
+  );
+}
+
 export function booleanLiteral(value: boolean): ts.BooleanLiteral {
   if (value) {
     return ts.factory.createTrue();
@@ -247,9 +338,15 @@ export function enumDeclaration(
   modifiers: readonly modifierKeys[] | readonly ts.Modifier[] | undefined,
   name: ts.Identifier | string,
   members: ts.EnumMember[],
+  isConst: boolean
 ): ts.EnumDeclaration {
+  const mods = modifiers?.map((m) => modifier(m)) ?? [];
+  if (isConst) {
+    // 'const' must come after any other modifiers, eg: 'export const enum'
+    mods.push(modifier("ConstKeyword"));
+  }
   return ts.factory.createEnumDeclaration(
-    modifiers?.map((m) => modifier(m)),
+    mods,
     identifier(name),
     members,
   );
@@ -272,6 +369,26 @@ export function arrayLiteral(
   return ts.factory.createArrayLiteralExpression(elements);
 }
 
+export function objectLiteral(
+  properties: ts.ObjectLiteralElementLike[],
+  spreads?: ts.Expression[]
+): ts.ObjectLiteralExpression {
+  const elements: ts.ObjectLiteralElementLike[] = (spreads ?? []).map((spread) =>
+    ts.factory.createSpreadAssignment(spread)
+  );
+  return ts.factory.createObjectLiteralExpression(
+    elements.concat(properties),
+    true
+  );
+}
+
+export function propertyAssignment(
+  name: string,
+  initializer: ts.Expression
+): ts.PropertyAssignment {
+  return ts.factory.createPropertyAssignment(propertyName(name), initializer);
+}
+
 export function typeOperatorNode(
   operator: "KeyOfKeyword" | "UniqueKeyword" | "ReadonlyKeyword",
   node: ts.TypeNode
@@ -289,6 +406,386 @@ export function intersectionType(types:TypeNode[]): ts.IntersectionTypeNode {
   return ts.factory.createIntersectionTypeNode(types);
 }
 
+// typeQuery is the type of a value, eg: 'typeof Foo'
+export function typeQuery(name: string | ts.EntityName): ts.TypeQueryNode {
+  return ts.factory.createTypeQueryNode(
+    typeof name === "string" ? identifier(name) : name
+  );
+}
+
+// indexedAccessType is the type of a property, eg: 'Foo["bar"]'
+export function indexedAccessType(
+  object: ts.TypeNode,
+  index: ts.TypeNode
+): ts.IndexedAccessTypeNode {
+  return ts.factory.createIndexedAccessTypeNode(object, index);
+}
+
+// asConst makes a value literal and readonly, eg: '{ foo: "bar" } as const'
+export function asConst(expression: ts.Expression): ts.AsExpression {
+  return ts.factory.createAsExpression(
+    expression,
+    ts.factory.createTypeReferenceNode("const")
+  );
+}
+
+// propertyAccess references the property of a value, eg: 'pkg.Foo.Bar'
+export function propertyAccess(
+  namespace: string,
+  object: string,
+  name: string
+): ts.PropertyAccessExpression {
+  const expression: ts.Expression = namespace
+    ? ts.factory.createPropertyAccessExpression(identifier(namespace), object)
+    : identifier(object);
+  return ts.factory.createPropertyAccessExpression(expression, name);
+}
+
+export function namespaceDecl(
+  modifiers: readonly modifierKeys[] | readonly ts.Modifier[] | undefined,
+  name: ts.Identifier | string,
+  statements: (ts.Statement | ts.Statement[])[],
+  global: boolean
+): ts.ModuleDeclaration {
+  // Declarations of multiple statements are flattened into the block.
+  const flat = statements.reduce<ts.Statement[]>((all, s) => all.concat(s), []);
+  return ts.factory.createModuleDeclaration(
+    modifiers?.map((m) => modifier(m)),
+    identifier(global ? "global" : name),
+    ts.factory.createModuleBlock(flat),
+    global ? ts.NodeFlags.GlobalAugmentation : ts.NodeFlags.Namespace
+  );
+}
+
+export function functionDecl(
+  modifiers: readonly modifierKeys[] | readonly ts.Modifier[] | undefined,
+  name: ts.Identifier | string,
+  parameters: ts.ParameterDeclaration[],
+  type: ts.TypeNode,
+  body?: ts.Expression
+): ts.FunctionDeclaration {
+  return ts.factory.createFunctionDeclaration(
+    modifiers?.map((m) => modifier(m)),
+    undefined, // Asterisk
+    identifier(name),
+    undefined, // Generics
+    parameters,
+    type,
+    body
+      ? ts.factory.createBlock([ts.factory.createReturnStatement(body)], true)
+      : undefined
+  );
+}
+
+// identifierExpression references a value by name, eg: 'pkg.decodeFoo'
+export function identifierExpression(
+  namespace: string,
+  name: string
+): ts.Expression {
+  return namespace
+    ? ts.factory.createPropertyAccessExpression(identifier(namespace), name)
+    : identifier(name);
+}
+
+// memberAccess references a property of any value, eg: 'value.items'. Names
+// that are not identifiers use an element access, eg: 'value[0]'.
+export function memberAccess(
+  expression: ts.Expression,
+  name: string
+): ts.Expression {
+  if (/^[A-Za-z_$][\w$]*$/.test(name)) {
+    return ts.factory.createPropertyAccessExpression(expression, name);
+  }
+  return ts.factory.createElementAccessExpression(
+    expression,
+    /^\d+$/.test(name) ? numericLiteral(name) : stringLiteral(name)
+  );
+}
+
+export function callExpression(
+  expression: ts.Expression,
+  args: ts.Expression[],
+  isNew: boolean
+): ts.Expression {
+  if (isNew) {
+    return ts.factory.createNewExpression(expression, undefined, args);
+  }
+  return ts.factory.createCallExpression(expression, undefined, args);
+}
+
+export function arrowFunction(
+  parameters: ts.ParameterDeclaration[],
+  body: ts.Expression
+): ts.ArrowFunction {
+  return ts.factory.createArrowFunction(
+    undefined,
+    undefined,
+    parameters,
+    undefined,
+    ts.factory.createToken(ts.SyntaxKind.EqualsGreaterThanToken),
+    // An object literal body must be wrapped in parentheses.
+    ts.isObjectLiteralExpression(body)
+      ? ts.factory.createParenthesizedExpression(body)
+      : body
+  );
+}
+
+export function conditionalExpression(
+  condition: ts.Expression,
+  whenTrue: ts.Expression,
+  whenFalse: ts.Expression
+): ts.ConditionalExpression {
+  return ts.factory.createConditionalExpression(
+    condition,
+    ts.factory.createToken(ts.SyntaxKind.QuestionToken),
+    whenTrue,
+    ts.factory.createToken(ts.SyntaxKind.ColonToken),
+    whenFalse
+  );
+}
+
+export function binaryExpression(
+  left: ts.Expression,
+  operator: "EqualsEqualsToken" | "EqualsEqualsEqualsToken" | "ExclamationEqualsToken" | "ExclamationEqualsEqualsToken",
+  right: ts.Expression
+): ts.BinaryExpression {
+  return ts.factory.createBinaryExpression(left, ts.SyntaxKind[operator], right);
+}
+
+// parsedType is a plain description of a parsed type node. It is returned as
+// json, and converted to the golang bindings.
+interface parsedType {
+  kind: string;
+  // keyword is the keyword, or type operator, name. Eg: "StringKeyword"
+  keyword?: string;
+  // value is the text of a literal.
+  value?: string;
+  // name is a reference, or type query, name split on ".".
+  name?: string[];
+  types?: parsedType[];
+  type?: parsedType;
+  index?: parsedType;
+  members?: parsedMember[];
+  parameters?: parsedMember[];
+  head?: string;
+  spans?: { type: parsedType; literal: string }[];
+}
+
+interface parsedMember {
+  name: string;
+  optional?: boolean;
+  readonly?: boolean;
+  rest?: boolean;
+  method?: boolean;
+  parameters?: parsedMember[];
+  type?: parsedType;
+}
+
+// parseType parses the text of a single typescript type, like
+// 'Record<string, Date>'.
+export function parseType(text: string): string {
+  const file = ts.createSourceFile(
+    "custom.ts",
+    `type custom = ${text};`,
+    ts.ScriptTarget.Latest,
+    true,
+    ts.ScriptKind.TS
+  );
+  const diagnostics: readonly ts.Diagnostic[] = (file as any).parseDiagnostics;
+  if (diagnostics && diagnostics.length > 0) {
+    throw new Error(
+      ts.flattenDiagnosticMessageText(diagnostics[0].messageText, "\n")
+    );
+  }
+
+  const statement = file.statements[0];
+  if (file.statements.length !== 1 || !ts.isTypeAliasDeclaration(statement)) {
+    throw new Error("expected a single type");
+  }
+  return JSON.stringify(describeType(statement.type));
+}
+
+function describeType(node: ts.TypeNode): parsedType {
+  switch (node.kind) {
+    case ts.SyntaxKind.AnyKeyword:
+      return { kind: "keyword", keyword: "AnyKeyword" };
+    case ts.SyntaxKind.BigIntKeyword:
+      return { kind: "keyword", keyword: "BigIntKeyword" };
+    case ts.SyntaxKind.BooleanKeyword:
+      return { kind: "keyword", keyword: "BooleanKeyword" };
+    case ts.SyntaxKind.NeverKeyword:
+      return { kind: "keyword", keyword: "NeverKeyword" };
+    case ts.SyntaxKind.NumberKeyword:
+      return { kind: "keyword", keyword: "NumberKeyword" };
+    case ts.SyntaxKind.ObjectKeyword:
+      return { kind: "keyword", keyword: "ObjectKeyword" };
+    case ts.SyntaxKind.StringKeyword:
+      return { kind: "keyword", keyword: "StringKeyword" };
+    case ts.SyntaxKind.SymbolKeyword:
+      return { kind: "keyword", keyword: "SymbolKeyword" };
+    case ts.SyntaxKind.UndefinedKeyword:
+      return { kind: "keyword", keyword: "UndefinedKeyword" };
+    case ts.SyntaxKind.UnknownKeyword:
+      return { kind: "keyword", keyword: "UnknownKeyword" };
+    case ts.SyntaxKind.VoidKeyword:
+      return { kind: "keyword", keyword: "VoidKeyword" };
+  }
+
+  if (ts.isParenthesizedTypeNode(node)) {
+    return describeType(node.type);
+  }
+  if (ts.isLiteralTypeNode(node)) {
+    return describeLiteral(node.literal);
+  }
+  if (ts.isTypeReferenceNode(node)) {
+    return {
+      kind: "reference",
+      name: entityName(node.typeName),
+      types: (node.typeArguments ?? []).map(describeType),
+    };
+  }
+  if (ts.isArrayTypeNode(node)) {
+    return { kind: "array", type: describeType(node.elementType) };
+  }
+  if (ts.isTupleTypeNode(node)) {
+    return { kind: "tuple", types: node.elements.map(describeType) };
+  }
+  if (ts.isUnionTypeNode(node)) {
+    return { kind: "union", types: node.types.map(describeType) };
+  }
+  if (ts.isIntersectionTypeNode(node)) {
+    return { kind: "intersection", types: node.types.map(describeType) };
+  }
+  if (ts.isTypeOperatorNode(node)) {
+    const operators: { [kind: number]: string } = {
+      [ts.SyntaxKind.KeyOfKeyword]: "KeyOfKeyword",
+      [ts.SyntaxKind.ReadonlyKeyword]: "ReadonlyKeyword",
+      [ts.SyntaxKind.UniqueKeyword]: "UniqueKeyword",
+    };
+    return {
+      kind: "operator",
+      keyword: operators[node.operator],
+      type: describeType(node.type),
+    };
+  }
+  if (ts.isTypeQueryNode(node) && !node.typeArguments) {
+    return { kind: "query", name: entityName(node.exprName) };
+  }
+  if (ts.isIndexedAccessTypeNode(node)) {
+    return {
+      kind: "indexed",
+      type: describeType(node.objectType),
+      index: describeType(node.indexType),
+    };
+  }
+  if (ts.isTemplateLiteralTypeNode(node)) {
+    return {
+      kind: "template",
+      head: node.head.text,
+      spans: node.templateSpans.map((span) => ({
+        type: describeType(span.type),
+        literal: span.literal.text,
+      })),
+    };
+  }
+  if (ts.isTypeLiteralNode(node)) {
+    return { kind: "object", members: node.members.map(describeMember) };
+  }
+  if (ts.isFunctionTypeNode(node) && !node.typeParameters) {
+    return {
+      kind: "function",
+      parameters: node.parameters.map(describeParameter),
+      type: describeType(node.type),
+    };
+  }
+  throw unsupported(node);
+}
+
+function describeLiteral(node: ts.LiteralTypeNode["literal"]): parsedType {
+  switch (node.kind) {
+    case ts.SyntaxKind.NullKeyword:
+      return { kind: "null" };
+    case ts.SyntaxKind.TrueKeyword:
+      return { kind: "boolean", value: "true" };
+    case ts.SyntaxKind.FalseKeyword:
+      return { kind: "boolean", value: "false" };
+    case ts.SyntaxKind.StringLiteral:
+    case ts.SyntaxKind.NoSubstitutionTemplateLiteral:
+      return { kind: "string", value: node.text };
+    case ts.SyntaxKind.NumericLiteral:
+      return { kind: "number", value: node.text };
+    case ts.SyntaxKind.BigIntLiteral:
+      return { kind: "bigint", value: node.text.slice(0, -1) };
+    case ts.SyntaxKind.PrefixUnaryExpression:
+      if (node.operator === ts.SyntaxKind.MinusToken) {
+        const operand = describeLiteral(node.operand as ts.LiteralExpression);
+        if (operand.kind === "number" || operand.kind === "bigint") {
+          return { kind: operand.kind, value: "-" + operand.value };
+        }
+      }
+  }
+  throw unsupported(node);
+}
+
+function describeMember(node: ts.TypeElement): parsedMember {
+  if (ts.isPropertySignature(node)) {
+    return {
+      name: memberName(node.name),
+      optional: node.questionToken !== undefined,
+      readonly: (node.modifiers ?? []).some(
+        (m) => m.kind === ts.SyntaxKind.ReadonlyKeyword
+      ),
+      type: node.type ? describeType(node.type) : undefined,
+    };
+  }
+  if (ts.isMethodSignature(node) && !node.typeParameters) {
+    return {
+      name: memberName(node.name),
+      optional: node.questionToken !== undefined,
+      method: true,
+      parameters: node.parameters.map(describeParameter),
+      type: node.type ? describeType(node.type) : undefined,
+    };
+  }
+  throw unsupported(node);
+}
+
+function describeParameter(node: ts.ParameterDeclaration): parsedMember {
+  if (!ts.isIdentifier(node.name)) {
+    throw unsupported(node);
+  }
+  return {
+    name: node.name.text,
+    optional: node.questionToken !== undefined,
+    rest: node.dotDotDotToken !== undefined,
+    type: node.type ? describeType(node.type) : undefined,
+  };
+}
+
+function memberName(name: ts.PropertyName): string {
+  if (
+    ts.isIdentifier(name) ||
+    ts.isStringLiteral(name) ||
+    ts.isNumericLiteral(name)
+  ) {
+    return name.text;
+  }
+  throw unsupported(name);
+}
+
+function entityName(name: ts.EntityName): string[] {
+  if (ts.isIdentifier(name)) {
+    return [name.text];
+  }
+  return entityName(name.left).concat(name.right.text);
+}
+
+function unsupported(node: ts.Node): Error {
+  return new Error(
+    `unsupported ${ts.SyntaxKind[node.kind]} "${node.getText()}"`
+  );
+}
+
 module.exports = {
   modifier: modifier,
   identifier: identifier,
@@ -299,6 +796,11 @@ module.exports = {
   interfaceDecl: interfaceDecl,
   literalKeyword: literalKeyword,
   homogeneousTupleType: homogeneousTupleType,
+  tupleType: tupleType,
+  functionType: functionType,
+  parameter: parameter,
+  methodSignature: methodSignature,
+  functionDecl: functionDecl,
   arrayType: arrayType,
   aliasDecl: aliasDecl,
   typeParameterDeclaration: typeParameterDeclaration,
@@ -308,15 +810,35 @@ module.exports = {
   heritageClause: heritageClause,
   stringLiteral: stringLiteral,
   numericLiteral: numericLiteral,
+  bigIntLiteral: bigIntLiteral,
+  prefixUnaryExpression: prefixUnaryExpression,
+  templateLiteralType: templateLiteralType,
+  templateLiteralTypeSpan: templateLiteralTypeSpan,
   booleanLiteral: booleanLiteral,
   literalTypeNode: literalTypeNode,
   variableStatement: variableStatement,
   variableDeclaration: variableDeclaration,
   variableDeclarationList: variableDeclarationList,
   arrayLiteral: arrayLiteral,
+  objectLiteral: objectLiteral,
+  propertyAssignment: propertyAssignment,
+  propertyName: propertyName,
   typeOperatorNode: typeOperatorNode,
   typeLiteralNode: typeLiteralNode,
   intersectionType: intersectionType,
+  typeQuery: typeQuery,
+  indexedAccessType: indexedAccessType,
+  asConst: asConst,
+  propertyAccess: propertyAccess,
   enumDeclaration: enumDeclaration,
   enumMember: enumMember,
+  qualifiedName: qualifiedName,
+  namespaceDecl: namespaceDecl,
+  identifierExpression: identifierExpression,
+  memberAccess: memberAccess,
+  callExpression: callExpression,
+  arrowFunction: arrowFunction,
+  conditionalExpression: conditionalExpression,
+  binaryExpression: binaryExpression,
+  parseType: parseType,
 };
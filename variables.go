@@ -0,0 +1,397 @@
+package guts
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"log/slog"
+	"reflect"
+
+	"github.com/fatih/structtag"
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/xerrors"
+
+	"github.com/coder/guts/bindings"
+)
+
+// GenerateVariables generates package level variables that are initialized
+// with a constant value or a composite literal. Composite literals of structs,
+// slices, arrays and maps are supported, as long as every value is a basic
+// constant or another supported composite literal.
+// Eg: var Defaults = Config{Name: "foo"} --> export const Defaults: Config = { name: "foo" }
+//
+// Variables can be reassigned at runtime, only the initial value is generated.
+func (p *GoParser) GenerateVariables() *GoParser {
+	p.generateVariables = true
+	return p
+}
+
+// generatedVarValue returns the value a package level variable is initialized
+// with, if it is a composite literal or a constant. Function calls and other
+// runtime values cannot be evaluated.
+func generatedVarValue(pkg *packages.Package, obj *types.Var) (ast.Expr, bool) {
+	expr := varValue(pkg, obj)
+	if expr == nil {
+		return nil, false
+	}
+	if _, ok := ast.Unparen(expr).(*ast.CompositeLit); ok {
+		return expr, true
+	}
+	tv, ok := pkg.TypesInfo.Types[expr]
+	return expr, ok && tv.Value != nil
+}
+
+// variableDeclaration returns the typescript declaration of a package level
+// variable. If the variable is not initialized with a supported value, the
+// second return is false.
+func (ts *Typescript) variableDeclaration(obj *types.Var) (*bindings.VariableStatement, bool, error) {
+	pkg, ok := ts.parsed.Pkgs[obj.Pkg().Path()]
+	if !ok {
+		return nil, false, nil
+	}
+
+	expr, ok := generatedVarValue(pkg, obj)
+	if !ok {
+		return nil, false, nil
+	}
+
+	value, err := ts.literalValue(pkg, expr)
+	if err != nil {
+		slog.Warn("variable is not generated, the value is not supported",
+			slog.String("var", qualifiedName(obj)), slog.String("error", err.Error()))
+		return nil, false, nil
+	}
+
+	varType, err := ts.typescriptType(obj.Type())
+	if err != nil {
+		return nil, false, xerrors.Errorf("variable type: %w", err)
+	}
+	// Maps are nullable, but the value is known to not be null.
+	if union, ok := varType.Value.(*bindings.UnionType); ok && len(union.Types) == 2 {
+		if _, isNull := value.(*bindings.Null); !isNull {
+			if _, ok := union.Types[1].(*bindings.Null); ok {
+				varType.Value = union.Types[0]
+			}
+		}
+	}
+
+	return &bindings.VariableStatement{
+		Modifiers: []bindings.Modifier{},
+		Declarations: &bindings.VariableDeclarationList{
+			Declarations: []*bindings.VariableDeclaration{
+				{
+					Name:            ts.parsed.Identifier(obj),
+					ExclamationMark: false,
+					Type:            varType.Value,
+					Initializer:     value,
+				},
+			},
+			Flags: bindings.NodeFlagsConstant,
+		},
+		Source: ts.location(obj),
+	}, true, nil
+}
+
+// literalValue evaluates a golang expression into a typescript value.
+func (ts *Typescript) literalValue(pkg *packages.Package, expr ast.Expr) (bindings.ExpressionType, error) {
+	expr = ast.Unparen(expr)
+	tv, ok := pkg.TypesInfo.Types[expr]
+	if !ok {
+		return nil, xerrors.Errorf("no type information for expression")
+	}
+	if err := ts.checkOverride(tv.Type); err != nil {
+		return nil, err
+	}
+
+	if tv.Value != nil {
+		return ts.constantLiteral(pkg, expr, tv)
+	}
+	if tv.IsNil() {
+		return &bindings.Null{}, nil
+	}
+
+	switch expr := expr.(type) {
+	case *ast.UnaryExpr:
+		if expr.Op == token.AND {
+			// &Foo{}
+			return ts.literalValue(pkg, expr.X)
+		}
+	case *ast.CompositeLit:
+		return ts.compositeLiteral(pkg, expr, tv.Type)
+	}
+	return nil, xerrors.Errorf("unsupported expression %T", expr)
+}
+
+// constantLiteral converts a constant. Constants of enums that are marshaled as
+// text use the text value.
+func (ts *Typescript) constantLiteral(pkg *packages.Package, expr ast.Expr, tv types.TypeAndValue) (bindings.ExpressionType, error) {
+	var ident *ast.Ident
+	switch e := expr.(type) {
+	case *ast.Ident:
+		ident = e
+	case *ast.SelectorExpr:
+		ident = e.Sel
+	}
+	if ident != nil {
+		if cnst, ok := pkg.TypesInfo.Uses[ident].(*types.Const); ok {
+			if text, ok := ts.parsed.enumTextValue(cnst); ok {
+				return text, nil
+			}
+		}
+	} else if named, ok := tv.Type.(*types.Named); ok && ts.parsed.marshalsAsText(named) {
+		// An untyped constant converted to a text enum, eg: 'Color(1)'.
+		cnst := types.NewConst(token.NoPos, named.Obj().Pkg(), "", named, tv.Value)
+		if text, ok := ts.parsed.enumTextValue(cnst); ok {
+			return text, nil
+		}
+	}
+
+	var value bindings.LiteralType
+	switch tv.Value.Kind() {
+	case constant.String:
+		value.Value = constant.StringVal(tv.Value)
+	case constant.Int:
+		return ts.integerValue(tv.Value.ExactString(), tv.Type, tv.Value)
+	case constant.Float:
+		value.Value, _ = constant.Float64Val(tv.Value)
+	case constant.Bool:
+		value.Value = constant.BoolVal(tv.Value)
+	default:
+		return nil, xerrors.Errorf("unsupported constant kind %s", tv.Value.Kind())
+	}
+	return &value, nil
+}
+
+func (ts *Typescript) compositeLiteral(pkg *packages.Package, lit *ast.CompositeLit, typ types.Type) (bindings.ExpressionType, error) {
+	if ptrType, ok := typ.Underlying().(*types.Pointer); ok {
+		// Elided '&T' in a composite literal
+		typ = ptrType.Elem()
+	}
+
+	switch underlying := typ.Underlying().(type) {
+	case *types.Struct:
+		values := make(map[*types.Var]ast.Expr)
+		for i, elt := range lit.Elts {
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				key, ok := kv.Key.(*ast.Ident)
+				if !ok {
+					return nil, xerrors.Errorf("unsupported struct key %T", kv.Key)
+				}
+				field, ok := pkg.TypesInfo.Uses[key].(*types.Var)
+				if !ok {
+					return nil, xerrors.Errorf("unknown struct field %q", key.Name)
+				}
+				values[field] = kv.Value
+				continue
+			}
+			values[underlying.Field(i)] = elt
+		}
+
+		obj := &bindings.ObjectLiteral{Properties: []*bindings.PropertyAssignment{}}
+		err := ts.structProperties(pkg, obj, underlying, values)
+		if err != nil {
+			return nil, err
+		}
+		return obj, nil
+	case *types.Slice, *types.Array:
+		array := &bindings.ArrayLiteralType{Elements: []bindings.ExpressionType{}}
+		for _, elt := range lit.Elts {
+			if _, ok := elt.(*ast.KeyValueExpr); ok {
+				return nil, xerrors.Errorf("indexed array elements are not supported")
+			}
+			v, err := ts.literalValue(pkg, elt)
+			if err != nil {
+				return nil, xerrors.Errorf("array element: %w", err)
+			}
+			array.Elements = append(array.Elements, v)
+		}
+		return array, nil
+	case *types.Map:
+		obj := &bindings.ObjectLiteral{Properties: []*bindings.PropertyAssignment{}}
+		for _, elt := range lit.Elts {
+			kv, ok := elt.(*ast.KeyValueExpr)
+			if !ok {
+				return nil, xerrors.Errorf("map element is not a key value pair")
+			}
+			key, ok := pkg.TypesInfo.Types[kv.Key]
+			if !ok || key.Value == nil {
+				return nil, xerrors.Errorf("map keys must be constants")
+			}
+			// Keys are converted the same way 'encoding/json' does, which
+			// includes text enums.
+			keyValue, err := ts.constantLiteral(pkg, ast.Unparen(kv.Key), key)
+			if err != nil {
+				return nil, xerrors.Errorf("map key: %w", err)
+			}
+			var name string
+			switch v := keyValue.(type) {
+			case *bindings.LiteralType:
+				name = fmt.Sprint(v.Value)
+			case *bindings.BigIntLiteral:
+				name = v.Value
+			default:
+				return nil, xerrors.Errorf("unsupported map key %T", v)
+			}
+
+			v, err := ts.literalValue(pkg, kv.Value)
+			if err != nil {
+				return nil, xerrors.Errorf("map value %q: %w", name, err)
+			}
+			obj.Properties = append(obj.Properties, &bindings.PropertyAssignment{
+				Name:        name,
+				Initializer: v,
+			})
+		}
+		return obj, nil
+	}
+	return nil, xerrors.Errorf("unsupported composite literal of type %s", typ.String())
+}
+
+// structProperties adds every json field of the struct to the object. Fields
+// that are not set use their zero value, as 'encoding/json' would. Embedded
+// structs without a json tag are flattened.
+func (ts *Typescript) structProperties(pkg *packages.Package, obj *bindings.ObjectLiteral, st *types.Struct, values map[*types.Var]ast.Expr) error {
+	for i := 0; i < st.NumFields(); i++ {
+		field := st.Field(i)
+		tags, err := structtag.Parse(st.Tag(i))
+		if err != nil {
+			return xerrors.Errorf("invalid struct tags on field %q: %w", field.Name(), err)
+		}
+
+		if field.Embedded() && reflect.StructTag(st.Tag(i)).Get("json") == "" {
+			embedded, ok := field.Type().Underlying().(*types.Struct)
+			if !ok {
+				return xerrors.Errorf("unsupported embedded field %q", field.Name())
+			}
+
+			// The embedded struct can be set as a whole, or not at all.
+			embeddedValues := make(map[*types.Var]ast.Expr)
+			if expr, ok := values[field]; ok {
+				lit, ok := ast.Unparen(expr).(*ast.CompositeLit)
+				if !ok {
+					return xerrors.Errorf("unsupported embedded field value %q", field.Name())
+				}
+				for j, elt := range lit.Elts {
+					if kv, ok := elt.(*ast.KeyValueExpr); ok {
+						if key, ok := kv.Key.(*ast.Ident); ok {
+							if f, ok := pkg.TypesInfo.Uses[key].(*types.Var); ok {
+								embeddedValues[f] = kv.Value
+							}
+						}
+						continue
+					}
+					embeddedValues[embedded.Field(j)] = elt
+				}
+			}
+			if err := ts.structProperties(pkg, obj, embedded, embeddedValues); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !field.Exported() {
+			continue
+		}
+
+		name := field.Name()
+		var optional, quoted bool
+		if jsonTag, err := tags.Get("json"); err == nil {
+			if jsonTag.Name == "-" && len(jsonTag.Options) == 0 {
+				continue
+			}
+			if jsonTag.Name != "" {
+				name = jsonTag.Name
+			}
+			optional = jsonTag.HasOption("omitempty") || jsonTag.HasOption("omitzero")
+			_, quoted = stringOptionType(field.Type())
+			quoted = quoted && jsonTag.HasOption("string")
+		}
+		if typescriptTag, err := tags.Get("typescript"); err == nil && typescriptTag.Name == "-" {
+			continue
+		}
+
+		expr, ok := values[field]
+		if !ok {
+			if optional {
+				// Zero values are omitted
+				continue
+			}
+			zero, err := ts.zeroValue(field.Type())
+			if err != nil {
+				return xerrors.Errorf("field %q: %w", field.Name(), err)
+			}
+			if quoted {
+				zero = quotedValue(zero)
+			}
+			obj.Properties = append(obj.Properties, &bindings.PropertyAssignment{
+				Name:        name,
+				Initializer: zero,
+			})
+			continue
+		}
+
+		v, err := ts.literalValue(pkg, expr)
+		if err != nil {
+			return xerrors.Errorf("field %q: %w", field.Name(), err)
+		}
+		if quoted {
+			v = quotedValue(v)
+		}
+		obj.Properties = append(obj.Properties, &bindings.PropertyAssignment{
+			Name:        name,
+			Initializer: v,
+		})
+	}
+	return nil
+}
+
+// zeroValue is the json value of the zero value of a type.
+func (ts *Typescript) zeroValue(typ types.Type) (bindings.ExpressionType, error) {
+	if err := ts.checkOverride(typ); err != nil {
+		return nil, err
+	}
+
+	switch underlying := typ.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case underlying.Info()&types.IsString != 0:
+			return &bindings.LiteralType{Value: ""}, nil
+		case underlying.Info()&types.IsBoolean != 0:
+			return &bindings.LiteralType{Value: false}, nil
+		case underlying.Info()&types.IsNumeric != 0:
+			if named, ok := typ.(*types.Named); ok {
+				zero := types.NewConst(token.NoPos, named.Obj().Pkg(), "", named, constant.MakeInt64(0))
+				if text, ok := ts.parsed.enumTextValue(zero); ok {
+					return text, nil
+				}
+			}
+			if underlying.Info()&types.IsInteger != 0 {
+				return ts.integerValue("0", typ, constant.MakeInt64(0))
+			}
+			return &bindings.LiteralType{Value: int64(0)}, nil
+		}
+	case *types.Pointer, *types.Slice, *types.Map, *types.Interface:
+		return &bindings.Null{}, nil
+	case *types.Struct:
+		obj := &bindings.ObjectLiteral{Properties: []*bindings.PropertyAssignment{}}
+		if err := ts.structProperties(nil, obj, underlying, nil); err != nil {
+			return nil, err
+		}
+		return obj, nil
+	}
+	return nil, xerrors.Errorf("unsupported zero value of type %s", typ.String())
+}
+
+// checkOverride returns an error if the type has a custom typescript type. The
+// golang value cannot be converted to match the custom type.
+func (ts *Typescript) checkOverride(typ types.Type) error {
+	if ptrType, ok := typ.(*types.Pointer); ok {
+		typ = ptrType.Elem()
+	}
+	if _, ok := ts.parsed.customOverride(typ); ok {
+		return xerrors.Errorf("values of type %s have a custom typescript type", typ.String())
+	}
+	return nil
+}
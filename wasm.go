@@ -0,0 +1,240 @@
+package guts
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"os"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/xerrors"
+
+	"github.com/coder/guts/bindings"
+)
+
+// globalNamespace is the namespace of declarations in the global scope.
+// These are grouped into a 'declare global { ... }' block.
+const globalNamespace = "global"
+
+// wasmAnnotation is the comment that describes the golang signature of a
+// function registered with 'syscall/js'.
+const wasmAnnotation = "guts:js"
+
+// WasmGlobals generates declarations for golang functions that are exposed to
+// javascript with 'syscall/js'. The functions must be registered on the global
+// object, and annotated with the golang signature of the function.
+//
+//	//guts:js func(name string, limit int) ([]User, error)
+//	js.Global().Set("listUsers", js.FuncOf(listUsers))
+//
+// Generates:
+//
+//	declare global {
+//	    function listUsers(name: string, limit: number): User[];
+//	}
+//	export {};
+//
+// The empty export makes the file a module, which 'declare global' requires.
+// The signature uses the options from 'FunctionTypes'. Registrations without
+// an annotation accept and return 'unknown'.
+//
+// Packages that use 'syscall/js' only build for 'GOOS=js GOARCH=wasm', so all
+// included packages are loaded for that target, not just the wasm packages.
+// Files excluded by build constraints, like '//go:build linux' or cgo files,
+// are not generated. An error is returned if packages were already included,
+// as they were loaded for a different target.
+func (p *GoParser) WasmGlobals() error {
+	if len(p.Pkgs) > 0 {
+		return xerrors.New("wasm globals must be enabled before any packages are included")
+	}
+	p.wasmGlobals = true
+	if p.config.Env == nil {
+		p.config.Env = os.Environ()
+	}
+	p.config.Env = append(p.config.Env, "GOOS=js", "GOARCH=wasm")
+	return nil
+}
+
+// parseWasmGlobals finds all functions registered on the javascript global
+// object in the package.
+func (ts *Typescript) parseWasmGlobals(pkg *packages.Package) error {
+	for _, file := range pkg.Syntax {
+		// Annotations are the comment on the line above the registration.
+		comments := make(map[int]*ast.CommentGroup)
+		for _, cg := range file.Comments {
+			comments[ts.parsed.fileSet.Position(cg.End()).Line] = cg
+		}
+
+		var err error
+		ast.Inspect(file, func(n ast.Node) bool {
+			if err != nil {
+				return false
+			}
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			name, ok := wasmGlobalName(pkg.TypesInfo, call)
+			if !ok {
+				return true
+			}
+
+			line := ts.parsed.fileSet.Position(call.Pos()).Line
+			err = ts.parseWasmGlobal(pkg, name, call.Pos(), comments[line-1])
+			if err != nil {
+				err = xerrors.Errorf("wasm global %q: %w", name, err)
+			}
+			return true
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ts *Typescript) parseWasmGlobal(pkg *packages.Package, name string, pos token.Pos, doc *ast.CommentGroup) error {
+	fn := &bindings.FunctionDeclaration{
+		Name:      wasmGlobalIdentifier(pkg, name),
+		Modifiers: []bindings.Modifier{},
+		Source:    ts.locationAt(pkg.Types, pos),
+	}
+
+	var signature string
+	var comments []*ast.Comment
+	var tags []string
+	if doc != nil {
+		for _, c := range doc.List {
+			text := strings.TrimSpace(strings.TrimPrefix(c.Text, "//"))
+			if sig, ok := strings.CutPrefix(text, wasmAnnotation); ok {
+				signature = strings.TrimSpace(sig)
+				continue
+			}
+			comments = append(comments, c)
+		}
+	}
+
+	if signature == "" {
+		// Without the golang signature, the arguments are unknown.
+		fn.Parameters = []*bindings.Parameter{
+			{Name: "args", Rest: true, Type: bindings.Array(ptr(bindings.KeywordUnknown))},
+		}
+		fn.Type = ptr(bindings.KeywordUnknown)
+		fn.LeadingComment("missing '//" + wasmAnnotation + " func(...)' annotation, arguments are unknown")
+	} else {
+		tv, err := types.Eval(ts.parsed.fileSet, pkg.Types, pos, signature)
+		if err != nil {
+			return xerrors.Errorf("annotation %q: %w", signature, err)
+		}
+		sig, ok := tv.Type.(*types.Signature)
+		if !ok || !tv.IsType() {
+			return xerrors.Errorf("annotation %q is not a function type", signature)
+		}
+
+		parsed, err := ts.functionType(sig, ts.parsed.functionOptions())
+		if err != nil {
+			return xerrors.Errorf("annotation %q: %w", signature, err)
+		}
+		if len(parsed.TypeParameters) > 0 {
+			return xerrors.Errorf("annotation %q: generic functions are not supported", signature)
+		}
+		fnType := parsed.Value.(*bindings.FunctionType)
+		fn.Parameters = fnType.Parameters
+		fn.Type = fnType.Type
+		for _, c := range parsed.RaisedComments {
+			fn.LeadingComment(c)
+		}
+		tags = parsed.RaisedTags
+	}
+
+	if ts.preserveComments && len(comments) > 0 {
+		fn.AppendComments(syntheticComments(true, &ast.CommentGroup{List: comments}))
+	}
+	for _, tag := range tags {
+		fn.JSDocTag(tag)
+	}
+
+	return ts.setNode(fn.Name, fn.Name.GoName(), typescriptNode{
+		Node: fn,
+	})
+}
+
+// wasmGlobalIdentifiers returns the identifiers of all functions registered on
+// the javascript global object in the package.
+func wasmGlobalIdentifiers(pkg *packages.Package) []bindings.Identifier {
+	var idents []bindings.Identifier
+	for _, file := range pkg.Syntax {
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			if name, ok := wasmGlobalName(pkg.TypesInfo, call); ok {
+				idents = append(idents, wasmGlobalIdentifier(pkg, name))
+			}
+			return true
+		})
+	}
+	return idents
+}
+
+// wasmGlobalIdentifier is the identifier of a function registered on the
+// javascript global object.
+func wasmGlobalIdentifier(pkg *packages.Package, name string) bindings.Identifier {
+	return bindings.Identifier{
+		Name:      name,
+		Package:   pkg.Types,
+		Namespace: globalNamespace,
+	}
+}
+
+// wasmGlobalName returns the name of the function registered by the call, if
+// it is 'js.Global().Set("name", js.FuncOf(...))'.
+func wasmGlobalName(info *types.Info, call *ast.CallExpr) (string, bool) {
+	set, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || set.Sel.Name != "Set" || len(call.Args) != 2 {
+		return "", false
+	}
+
+	global, ok := set.X.(*ast.CallExpr)
+	if !ok {
+		return "", false
+	}
+	globalFun, ok := global.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return "", false
+	}
+	if !isWasmObject(info.Uses[globalFun.Sel], "Global") {
+		return "", false
+	}
+	// Only functions are registered, other values are skipped.
+	// js.Global().Set("version", js.ValueOf("1"))
+	if !isWasmFunc(info, call.Args[1]) {
+		return "", false
+	}
+
+	name := info.Types[call.Args[0]].Value
+	if name == nil || name.Kind() != constant.String {
+		return "", false
+	}
+	return constant.StringVal(name), true
+}
+
+// isWasmFunc returns true if the expression is a 'js.FuncOf' call, or a
+// 'js.Func' value.
+func isWasmFunc(info *types.Info, expr ast.Expr) bool {
+	if call, ok := expr.(*ast.CallExpr); ok {
+		if fun, ok := call.Fun.(*ast.SelectorExpr); ok && isWasmObject(info.Uses[fun.Sel], "FuncOf") {
+			return true
+		}
+	}
+	named, ok := info.TypeOf(expr).(*types.Named)
+	return ok && isWasmObject(named.Obj(), "Func")
+}
+
+// isWasmObject returns true if the object is the named 'syscall/js' object.
+func isWasmObject(obj types.Object, name string) bool {
+	return obj != nil && obj.Pkg() != nil && obj.Pkg().Path() == "syscall/js" && obj.Name() == name
+}